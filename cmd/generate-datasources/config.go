@@ -35,10 +35,36 @@ type Entity struct {
 	ObjectNamePropertyDefaultValue string `yaml:"object-name-property-default-value"`
 
 	// Method of the Incus client to get the resource, e.g. `GetNetwork`.
+	// If not set, the data source for a single resource is not generated,
+	// e.g. because it is implemented manually.
 	//
 	// E.g. from https://github.com/lxc/incus/blob/3da8fcd06c4f7ee3cb9388127e6071244db7ac8f/client/incus_networks.go#L104
 	IncusGetMethod string `yaml:"incus-get-method"`
 
+	// Method of the Incus client to list the resources with server side
+	// filtering, e.g. `GetNetworksWithFilter`. If set, an additional data
+	// source with the plural name of the resource is generated, which lists
+	// all the resources matching the given filters.
+	//
+	// The method is called with the name of the parent (if any), the
+	// arguments from `incus-list-method-args` (if any) and the list of
+	// filters, in this order.
+	IncusListMethod string `yaml:"incus-list-method"`
+
+	// Additional arguments passed to the list method before the filters.
+	// The arguments are Go expressions and are added to the generated code
+	// as-is, e.g. `api.InstanceTypeAny` for `GetInstancesWithFilter`.
+	IncusListMethodArgs []string `yaml:"incus-list-method-args"`
+
+	// Plural name of the resource in snake case, used for the list data
+	// source. Defaults to the name of the resource with an `s` appended.
+	PluralName string `yaml:"plural-name"`
+
+	// Example filter used in the documentation of the list data source.
+	// Defaults to `config.user.role=web` for resources with a config
+	// attribute and to `<name>=<default value>` otherwise.
+	ListFilterExample string `yaml:"list-filter-example"`
+
 	// Name of the parent entity, if any.
 	// Resources like network forwards have a parent, in this case a network.
 	// If this is the case, the name of the parent needs to be specidied.
@@ -54,6 +80,11 @@ type Entity struct {
 	// target, `has-target` needs to be set to `true`.
 	HasTarget bool `yaml:"has-target"`
 
+	// If a resource has no description attribute.
+	// Most resources do have a description attribute. If this is not the
+	// case, `has-no-description` needs to be set to `true`.
+	HasNoDescription bool `yaml:"has-no-description"`
+
 	// If a resource has no status attribute.
 	// Most resources do have a status attribute. If this is not the case,
 	// `has-no-status` needs to be set to `true`.
@@ -93,7 +124,7 @@ type Entity struct {
 	//   * `project`
 	//   * `target`
 	//   * `remote`
-	//   * `description`
+	//   * `description` (if `has-no-description` is not set to `true`)
 	//   * `config` (if `has-no-config` is not set to `true`)
 	//   * `status` (if `has-no-status` is not set to `true`)
 	//   * `location` (if `has-location` is set to `true`)
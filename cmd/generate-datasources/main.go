@@ -46,19 +46,44 @@ var targets = []struct {
 	// Type of the target file, e.g. `go`. Mainly used to control the post
 	// processing, e.g. for `go` files automated formatting is applied.
 	TargetFileType FileType
+
+	// Enabled reports, if the target is generated for the given entity.
+	Enabled func(args entityArgs) bool
 }{
 	{
 		TemplateName:   "datasource.go.gotmpl",
 		TargetName:     "internal/{{ .PackageName }}/datasource_{{ .Name }}_gen.go",
 		TargetFileType: FileTypeGo,
+		Enabled:        hasGetMethod,
 	},
 	{
 		TemplateName:   "docs.md.gotmpl",
 		TargetName:     "docs/data-sources/{{ .Name }}.md",
 		TargetFileType: FileTypeMarkdown,
+		Enabled:        hasGetMethod,
+	},
+	{
+		TemplateName:   "datasource_list.go.gotmpl",
+		TargetName:     "internal/{{ .PackageName }}/datasource_{{ .PluralName }}_gen.go",
+		TargetFileType: FileTypeGo,
+		Enabled:        hasListMethod,
+	},
+	{
+		TemplateName:   "docs_list.md.gotmpl",
+		TargetName:     "docs/data-sources/{{ .PluralName }}.md",
+		TargetFileType: FileTypeMarkdown,
+		Enabled:        hasListMethod,
 	},
 }
 
+func hasGetMethod(args entityArgs) bool {
+	return args.IncusGetMethod != ""
+}
+
+func hasListMethod(args entityArgs) bool {
+	return args.IncusListMethod != ""
+}
+
 // globalTarges is a list of files, which are only generated once and not
 // for each resource seperatly, thous global.
 // This is generally used for cases, where all the generated are mentioned
@@ -104,6 +129,19 @@ type entityArgs struct {
 	// Value from `incus-get-method`
 	IncusGetMethod string
 
+	// Value from `incus-list-method`
+	IncusListMethod string
+
+	// Value from `incus-list-method-args`
+	IncusListMethodArgs []string
+
+	// Value from `plural-name` or if not set, the name with an `s` appended.
+	PluralName string
+
+	// Value from `list-filter-example` or if not set, a filter based on the
+	// config or the name defining property.
+	ListFilterExample string
+
 	// `true`, if `partent` is not empty.
 	HasParent bool
 
@@ -116,6 +154,9 @@ type entityArgs struct {
 	// Value from `has-target`.
 	HasTarget bool
 
+	// Inverted value from `has-no-description`.
+	HasDescription bool
+
 	// Inverted value from `has-no-status`.
 	HasStatus bool
 
@@ -168,6 +209,18 @@ func main() {
 			cfg[name].ObjectNamePropertyDefaultValue = "default"
 		}
 
+		if entity.PluralName == "" {
+			cfg[name].PluralName = name + "s"
+		}
+
+		if entity.ListFilterExample == "" {
+			if entity.HasNoConfig {
+				cfg[name].ListFilterExample = entity.ObjectNamePropertyName + "=" + entity.ObjectNamePropertyDefaultValue
+			} else {
+				cfg[name].ListFilterExample = "config.user.role=web"
+			}
+		}
+
 		slog.DebugContext(ctx, "entity config", slog.String("name", name), slog.Any("config", entity))
 	}
 
@@ -192,9 +245,14 @@ func main() {
 			ObjectNamePropertyName:         entity.ObjectNamePropertyName,
 			ObjectNamePropertyDefaultValue: entity.ObjectNamePropertyDefaultValue,
 			IncusGetMethod:                 entity.IncusGetMethod,
+			IncusListMethod:                entity.IncusListMethod,
+			IncusListMethodArgs:            entity.IncusListMethodArgs,
+			PluralName:                     entity.PluralName,
+			ListFilterExample:              entity.ListFilterExample,
 			HasParent:                      entity.ParentName != "",
 			ParentName:                     entity.ParentName,
 			HasProject:                     !entity.HasNoProject,
+			HasDescription:                 !entity.HasNoDescription,
 			HasTarget:                      entity.HasTarget,
 			HasStatus:                      !entity.HasNoStatus,
 			HasConfig:                      !entity.HasNoConfig,
@@ -216,6 +274,10 @@ func main() {
 				continue
 			}
 
+			if !target.Enabled(args) {
+				continue
+			}
+
 			slog.InfoContext(ctx, "generating", slog.String("name", args.Name), slog.String("template", target.TemplateName))
 
 			filename := strings.Builder{}
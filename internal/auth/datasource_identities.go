@@ -0,0 +1,158 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/lxc/incus/v7/shared/api"
+
+	"github.com/lxc/terraform-provider-incus/internal/errors"
+	provider_config "github.com/lxc/terraform-provider-incus/internal/provider-config"
+)
+
+type IdentitiesDataSourceModel struct {
+	AuthenticationMethod types.String `tfsdk:"authentication_method"`
+	Identities           types.List   `tfsdk:"identities"`
+	Remote               types.String `tfsdk:"remote"`
+}
+
+type IdentitiesDataSource struct {
+	provider *provider_config.IncusProviderConfig
+}
+
+func NewIdentitiesDataSource() datasource.DataSource {
+	return &IdentitiesDataSource{}
+}
+
+func (d *IdentitiesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_identities", req.ProviderTypeName)
+}
+
+func (d *IdentitiesDataSource) Schema(_ context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"authentication_method": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(authenticationMethodTLS, authenticationMethodOIDC),
+				},
+			},
+
+			"identities": schema.ListAttribute{
+				Computed:    true,
+				ElementType: getIdentityObjectType(),
+			},
+
+			"remote": schema.StringAttribute{
+				Optional: true,
+			},
+		},
+	}
+}
+
+func (d *IdentitiesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	data := req.ProviderData
+	if data == nil {
+		return
+	}
+
+	provider, ok := data.(*provider_config.IncusProviderConfig)
+	if !ok {
+		resp.Diagnostics.Append(errors.NewProviderDataTypeError(req.ProviderData))
+		return
+	}
+
+	d.provider = provider
+}
+
+func (d *IdentitiesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state IdentitiesDataSourceModel
+
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	remote := state.Remote.ValueString()
+	server, err := d.provider.InstanceServer(remote, "", "")
+	if err != nil {
+		resp.Diagnostics.Append(errors.NewInstanceServerError(err))
+		return
+	}
+
+	var identities []api.Identity
+	authMethod := state.AuthenticationMethod.ValueString()
+	if authMethod != "" {
+		identities, err = server.GetIdentitiesByAuthenticationMethod(authMethod)
+	} else {
+		identities, err = server.GetIdentities()
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to retrieve identities", err.Error())
+		return
+	}
+
+	identityList, diags := toIdentityListType(ctx, identities)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.Identities = identityList
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func toIdentityListType(ctx context.Context, identities []api.Identity) (types.List, diag.Diagnostics) {
+	identityObjectType := getIdentityObjectType()
+	nilList := types.ListNull(identityObjectType)
+
+	identityList := make([]attr.Value, 0, len(identities))
+	for _, identity := range identities {
+		groups, diags := types.ListValueFrom(ctx, types.StringType, identity.Groups)
+		if diags.HasError() {
+			return nilList, diags
+		}
+
+		identityObjectMap := map[string]attr.Value{
+			"authentication_method": types.StringValue(identity.AuthenticationMethod),
+			"type":                  types.StringValue(identity.Type),
+			"identifier":            types.StringValue(identity.Identifier),
+			"name":                  types.StringValue(identity.Name),
+			"groups":                groups,
+		}
+
+		identityObject, diags := types.ObjectValue(identityObjectType.AttrTypes, identityObjectMap)
+		if diags.HasError() {
+			return nilList, diags
+		}
+
+		identityList = append(identityList, identityObject)
+	}
+
+	return types.ListValue(identityObjectType, identityList)
+}
+
+func getIdentityObjectType() types.ObjectType {
+	return types.ObjectType{
+		AttrTypes: map[string]attr.Type{
+			"authentication_method": types.StringType,
+			"type":                  types.StringType,
+			"identifier":            types.StringType,
+			"name":                  types.StringType,
+			"groups": types.ListType{
+				ElemType: types.StringType,
+			},
+		},
+	}
+}
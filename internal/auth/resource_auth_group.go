@@ -0,0 +1,423 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	incus "github.com/lxc/incus/v7/client"
+	"github.com/lxc/incus/v7/shared/api"
+
+	"github.com/lxc/terraform-provider-incus/internal/common"
+	"github.com/lxc/terraform-provider-incus/internal/errors"
+	provider_config "github.com/lxc/terraform-provider-incus/internal/provider-config"
+)
+
+type AuthGroupModel struct {
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"`
+	Permissions types.Set    `tfsdk:"permission"`
+	Remote      types.String `tfsdk:"remote"`
+}
+
+type AuthGroupPermissionModel struct {
+	Entitlement types.String `tfsdk:"entitlement"`
+	EntityType  types.String `tfsdk:"entity_type"`
+	URL         types.String `tfsdk:"url"`
+}
+
+type AuthGroupResource struct {
+	provider *provider_config.IncusProviderConfig
+}
+
+func NewAuthGroupResource() resource.Resource {
+	return &AuthGroupResource{}
+}
+
+func (r *AuthGroupResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_auth_group", req.ProviderTypeName)
+}
+
+func (r *AuthGroupResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+
+			"description": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(""),
+			},
+
+			"remote": schema.StringAttribute{
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"permission": schema.SetNestedBlock{
+				Description: "Permission granted to the group",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"entitlement": schema.StringAttribute{
+							Required:    true,
+							Description: "The entitlement granted on the entity, e.g. can_view.",
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
+						},
+
+						"entity_type": schema.StringAttribute{
+							Required:    true,
+							Description: "The type of the entity, e.g. project or instance.",
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
+						},
+
+						"url": schema.StringAttribute{
+							Required:    true,
+							Description: "The API URL of the entity, e.g. /1.0/projects/default.",
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *AuthGroupResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	data := req.ProviderData
+	if data == nil {
+		return
+	}
+
+	provider, ok := data.(*provider_config.IncusProviderConfig)
+	if !ok {
+		resp.Diagnostics.Append(errors.NewProviderDataTypeError(req.ProviderData))
+		return
+	}
+
+	r.provider = provider
+}
+
+func (r *AuthGroupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan AuthGroupModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	remote := plan.Remote.ValueString()
+	server, err := r.provider.InstanceServer(remote, "", "")
+	if err != nil {
+		resp.Diagnostics.Append(errors.NewInstanceServerError(err))
+		return
+	}
+
+	permissions, diags := ToPermissionList(ctx, plan.Permissions)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	group := api.AuthGroupsPost{
+		AuthGroupPost: api.AuthGroupPost{
+			Name: plan.Name.ValueString(),
+		},
+		AuthGroupPut: api.AuthGroupPut{
+			Description: plan.Description.ValueString(),
+			Permissions: permissions,
+		},
+	}
+
+	err = server.CreateAuthGroup(group)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to create auth group %q", group.Name), err.Error())
+		return
+	}
+
+	diags = r.SyncState(ctx, &resp.State, server, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *AuthGroupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state AuthGroupModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	remote := state.Remote.ValueString()
+	server, err := r.provider.InstanceServer(remote, "", "")
+	if err != nil {
+		resp.Diagnostics.Append(errors.NewInstanceServerError(err))
+		return
+	}
+
+	diags = r.SyncState(ctx, &resp.State, server, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *AuthGroupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan AuthGroupModel
+	var state AuthGroupModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	remote := plan.Remote.ValueString()
+	server, err := r.provider.InstanceServer(remote, "", "")
+	if err != nil {
+		resp.Diagnostics.Append(errors.NewInstanceServerError(err))
+		return
+	}
+
+	groupName := plan.Name.ValueString()
+	group, etag, err := server.GetAuthGroup(groupName)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to retrieve auth group %q", groupName), err.Error())
+		return
+	}
+
+	oldPermissions, diags := ToPermissionList(ctx, state.Permissions)
+	resp.Diagnostics.Append(diags...)
+
+	newPermissions, diags := ToPermissionList(ctx, plan.Permissions)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	addedPermissions, removedPermissions := diffPermissions(oldPermissions, newPermissions)
+
+	// Apply only the changed permissions on top of the permissions
+	// currently present on the server.
+	permissions := mergePermissions(group.Permissions, addedPermissions, removedPermissions)
+
+	groupReq := api.AuthGroupPut{
+		Description: plan.Description.ValueString(),
+		Permissions: permissions,
+	}
+
+	err = server.UpdateAuthGroup(groupName, groupReq, etag)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to update auth group %q", groupName), err.Error())
+		return
+	}
+
+	diags = r.SyncState(ctx, &resp.State, server, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *AuthGroupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state AuthGroupModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	remote := state.Remote.ValueString()
+	server, err := r.provider.InstanceServer(remote, "", "")
+	if err != nil {
+		resp.Diagnostics.Append(errors.NewInstanceServerError(err))
+		return
+	}
+
+	groupName := state.Name.ValueString()
+	err = server.DeleteAuthGroup(groupName)
+	if err != nil && !errors.IsNotFoundError(err) {
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to remove auth group %q", groupName), err.Error())
+	}
+}
+
+func (r *AuthGroupResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	meta := common.ImportMetadata{
+		ResourceName:   "auth_group",
+		RequiredFields: []string{"name"},
+	}
+
+	fields, diag := meta.ParseImportID(req.ID)
+	if diag != nil {
+		resp.Diagnostics.Append(diag)
+		return
+	}
+
+	for k, v := range fields {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(k), v)...)
+	}
+}
+
+// SyncState fetches the server's current state for an auth group and updates
+// the provided model. It then applies this updated model as the new state
+// in Terraform.
+func (r *AuthGroupResource) SyncState(ctx context.Context, tfState *tfsdk.State, server incus.InstanceServer, m AuthGroupModel) diag.Diagnostics {
+	var respDiags diag.Diagnostics
+
+	groupName := m.Name.ValueString()
+	group, _, err := server.GetAuthGroup(groupName)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			tfState.RemoveResource(ctx)
+			return nil
+		}
+
+		respDiags.AddError(fmt.Sprintf("Failed to retrieve auth group %q", groupName), err.Error())
+		return respDiags
+	}
+
+	permissions, diags := ToPermissionSetType(ctx, group.Permissions)
+	respDiags.Append(diags...)
+
+	if respDiags.HasError() {
+		return respDiags
+	}
+
+	m.Name = types.StringValue(group.Name)
+	m.Description = types.StringValue(group.Description)
+	m.Permissions = permissions
+
+	return tfState.Set(ctx, &m)
+}
+
+// ToPermissionList converts permission blocks of type types.Set into
+// a list of api.Permission.
+func ToPermissionList(ctx context.Context, permissionSet types.Set) ([]api.Permission, diag.Diagnostics) {
+	if permissionSet.IsNull() || permissionSet.IsUnknown() {
+		return []api.Permission{}, nil
+	}
+
+	permissionModelList := make([]AuthGroupPermissionModel, 0, len(permissionSet.Elements()))
+	diags := permissionSet.ElementsAs(ctx, &permissionModelList, false)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	permissions := make([]api.Permission, 0, len(permissionModelList))
+	for _, permissionModel := range permissionModelList {
+		permission := api.Permission{
+			Entitlement:     permissionModel.Entitlement.ValueString(),
+			EntityType:      permissionModel.EntityType.ValueString(),
+			EntityReference: permissionModel.URL.ValueString(),
+		}
+
+		permissions = append(permissions, permission)
+	}
+
+	return permissions, diags
+}
+
+// ToPermissionSetType converts a list of api.Permission into permission
+// blocks of type types.Set.
+func ToPermissionSetType(ctx context.Context, permissions []api.Permission) (types.Set, diag.Diagnostics) {
+	permissionList := make([]AuthGroupPermissionModel, 0, len(permissions))
+
+	for _, p := range permissions {
+		permission := AuthGroupPermissionModel{
+			Entitlement: types.StringValue(p.Entitlement),
+			EntityType:  types.StringValue(p.EntityType),
+			URL:         types.StringValue(p.EntityReference),
+		}
+
+		permissionList = append(permissionList, permission)
+	}
+
+	permissionType := map[string]attr.Type{
+		"entitlement": types.StringType,
+		"entity_type": types.StringType,
+		"url":         types.StringType,
+	}
+
+	return types.SetValueFrom(ctx, types.ObjectType{AttrTypes: permissionType}, permissionList)
+}
+
+// mergePermissions returns the current permissions without the removed ones
+// and with the added ones. Permissions are not duplicated.
+func mergePermissions(current, added, removed []api.Permission) []api.Permission {
+	removedMap := make(map[api.Permission]struct{}, len(removed))
+	for _, permission := range removed {
+		removedMap[permission] = struct{}{}
+	}
+
+	seen := make(map[api.Permission]struct{}, len(current)+len(added))
+	permissions := make([]api.Permission, 0, len(current)+len(added))
+
+	for _, permission := range append(slices.Clone(current), added...) {
+		_, isRemoved := removedMap[permission]
+		_, isSeen := seen[permission]
+		if isRemoved || isSeen {
+			continue
+		}
+
+		seen[permission] = struct{}{}
+		permissions = append(permissions, permission)
+	}
+
+	return permissions
+}
+
+func diffPermissions(oldPermissions, newPermissions []api.Permission) (added, removed []api.Permission) {
+	oldPermissionMap := make(map[api.Permission]struct{})
+	for _, permission := range oldPermissions {
+		oldPermissionMap[permission] = struct{}{}
+	}
+
+	newPermissionMap := make(map[api.Permission]struct{})
+	for _, permission := range newPermissions {
+		newPermissionMap[permission] = struct{}{}
+	}
+
+	for permission := range newPermissionMap {
+		if _, exists := oldPermissionMap[permission]; !exists {
+			added = append(added, permission)
+		}
+	}
+
+	for permission := range oldPermissionMap {
+		if _, exists := newPermissionMap[permission]; !exists {
+			removed = append(removed, permission)
+		}
+	}
+
+	return added, removed
+}
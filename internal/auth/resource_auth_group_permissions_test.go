@@ -0,0 +1,68 @@
+package auth
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/lxc/incus/v7/shared/api"
+)
+
+func TestAuthGroup_mergePermissions(t *testing.T) {
+	viewServer := api.Permission{EntityType: "server", EntityReference: "/1.0", Entitlement: "viewer"}
+	adminServer := api.Permission{EntityType: "server", EntityReference: "/1.0", Entitlement: "admin"}
+	viewProject := api.Permission{EntityType: "project", EntityReference: "/1.0/projects/default", Entitlement: "viewer"}
+	operateProject := api.Permission{EntityType: "project", EntityReference: "/1.0/projects/default", Entitlement: "operator"}
+
+	tests := []struct {
+		name     string
+		current  []api.Permission
+		added    []api.Permission
+		removed  []api.Permission
+		expected []api.Permission
+	}{
+		{
+			name:     "unchanged permissions are kept",
+			current:  []api.Permission{viewServer, viewProject},
+			added:    []api.Permission{operateProject},
+			removed:  nil,
+			expected: []api.Permission{viewServer, viewProject, operateProject},
+		},
+		{
+			name:     "changed entitlement",
+			current:  []api.Permission{viewServer, viewProject},
+			added:    []api.Permission{adminServer},
+			removed:  []api.Permission{viewServer},
+			expected: []api.Permission{viewProject, adminServer},
+		},
+		{
+			name:     "permissions added outside of Terraform are kept",
+			current:  []api.Permission{viewServer, operateProject},
+			added:    nil,
+			removed:  []api.Permission{viewServer},
+			expected: []api.Permission{operateProject},
+		},
+		{
+			name:     "added permission already present",
+			current:  []api.Permission{viewServer},
+			added:    []api.Permission{viewServer},
+			removed:  nil,
+			expected: []api.Permission{viewServer},
+		},
+		{
+			name:     "remove all",
+			current:  []api.Permission{viewServer},
+			added:    nil,
+			removed:  []api.Permission{viewServer},
+			expected: []api.Permission{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := mergePermissions(tt.current, tt.added, tt.removed)
+			if !reflect.DeepEqual(actual, tt.expected) {
+				t.Fatalf("mergePermissions() = %v, want %v", actual, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package auth_test
+
+import (
+	"fmt"
+	"testing"
+
+	petname "github.com/dustinkirkland/golang-petname"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/lxc/terraform-provider-incus/internal/acctest"
+)
+
+func TestAccAuthGroup_basic(t *testing.T) {
+	groupName := petname.Generate(2, "-")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			acctest.PreCheck(t)
+			acctest.PreCheckAPIExtensions(t, "access_management")
+		},
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAuthGroup_basic(groupName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_auth_group.group1", "name", groupName),
+					resource.TestCheckResourceAttr("incus_auth_group.group1", "description", ""),
+					resource.TestCheckResourceAttr("incus_auth_group.group1", "permission.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAuthGroup_permissions(t *testing.T) {
+	groupName := petname.Generate(2, "-")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			acctest.PreCheck(t)
+			acctest.PreCheckAPIExtensions(t, "access_management")
+		},
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAuthGroup_permissions(groupName, "can_view"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_auth_group.group1", "name", groupName),
+					resource.TestCheckResourceAttr("incus_auth_group.group1", "description", "Operators"),
+					resource.TestCheckResourceAttr("incus_auth_group.group1", "permission.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("incus_auth_group.group1", "permission.*", map[string]string{
+						"entitlement": "can_view",
+						"entity_type": "project",
+						"url":         "/1.0/projects/default",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("incus_auth_group.group1", "permission.*", map[string]string{
+						"entitlement": "viewer",
+						"entity_type": "server",
+						"url":         "/1.0",
+					}),
+				),
+			},
+			{
+				Config: testAccAuthGroup_permissions(groupName, "operator"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_auth_group.group1", "permission.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("incus_auth_group.group1", "permission.*", map[string]string{
+						"entitlement": "operator",
+						"entity_type": "project",
+						"url":         "/1.0/projects/default",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAuthGroup_importBasic(t *testing.T) {
+	groupName := petname.Generate(2, "-")
+	resourceName := "incus_auth_group.group1"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			acctest.PreCheck(t)
+			acctest.PreCheckAPIExtensions(t, "access_management")
+		},
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAuthGroup_permissions(groupName, "can_view"),
+			},
+			{
+				ResourceName:                         resourceName,
+				ImportStateId:                        groupName,
+				ImportStateVerifyIdentifierAttribute: "name",
+				ImportState:                          true,
+				ImportStateVerify:                    true,
+			},
+		},
+	})
+}
+
+func testAccAuthGroup_basic(name string) string {
+	return fmt.Sprintf(`
+resource "incus_auth_group" "group1" {
+  name = "%s"
+}
+`, name)
+}
+
+func testAccAuthGroup_permissions(name string, projectEntitlement string) string {
+	return fmt.Sprintf(`
+resource "incus_auth_group" "group1" {
+  name        = "%s"
+  description = "Operators"
+
+  permission {
+    entitlement = "%s"
+    entity_type = "project"
+    url         = "/1.0/projects/default"
+  }
+
+  permission {
+    entitlement = "viewer"
+    entity_type = "server"
+    url         = "/1.0"
+  }
+}
+`, name, projectEntitlement)
+}
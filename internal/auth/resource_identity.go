@@ -0,0 +1,353 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	incus "github.com/lxc/incus/v7/client"
+	"github.com/lxc/incus/v7/shared/api"
+
+	"github.com/lxc/terraform-provider-incus/internal/errors"
+	provider_config "github.com/lxc/terraform-provider-incus/internal/provider-config"
+)
+
+// Supported identity authentication methods.
+const (
+	authenticationMethodTLS  = "tls"
+	authenticationMethodOIDC = "oidc"
+)
+
+type IdentityModel struct {
+	AuthenticationMethod types.String `tfsdk:"authentication_method"`
+	Name                 types.String `tfsdk:"name"`
+	Groups               types.Set    `tfsdk:"groups"`
+	Remote               types.String `tfsdk:"remote"`
+
+	// Computed.
+	Identifier types.String `tfsdk:"identifier"`
+	Type       types.String `tfsdk:"type"`
+}
+
+// IdentityResource manages the group membership of an identity known to
+// the server. Identities themselves are created when a client certificate
+// is trusted or an OIDC user logs in, so the resource never creates or
+// removes them.
+type IdentityResource struct {
+	provider *provider_config.IncusProviderConfig
+}
+
+func NewIdentityResource() resource.Resource {
+	return &IdentityResource{}
+}
+
+func (r *IdentityResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_identity", req.ProviderTypeName)
+}
+
+func (r *IdentityResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"authentication_method": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf(authenticationMethodTLS, authenticationMethodOIDC),
+				},
+			},
+
+			"name": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+
+			"groups": schema.SetAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					// Prevent empty values.
+					setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+
+			"remote": schema.StringAttribute{
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+
+			// Computed.
+
+			"identifier": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"type": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *IdentityResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	data := req.ProviderData
+	if data == nil {
+		return
+	}
+
+	provider, ok := data.(*provider_config.IncusProviderConfig)
+	if !ok {
+		resp.Diagnostics.Append(errors.NewProviderDataTypeError(req.ProviderData))
+		return
+	}
+
+	r.provider = provider
+}
+
+func (r *IdentityResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan IdentityModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	remote := plan.Remote.ValueString()
+	server, err := r.provider.InstanceServer(remote, "", "")
+	if err != nil {
+		resp.Diagnostics.Append(errors.NewInstanceServerError(err))
+		return
+	}
+
+	// The identity must already exist, so creating the resource only
+	// takes over management of its groups.
+	diags = r.updateIdentityGroups(ctx, server, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = r.SyncState(ctx, &resp.State, server, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *IdentityResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state IdentityModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	remote := state.Remote.ValueString()
+	server, err := r.provider.InstanceServer(remote, "", "")
+	if err != nil {
+		resp.Diagnostics.Append(errors.NewInstanceServerError(err))
+		return
+	}
+
+	diags = r.SyncState(ctx, &resp.State, server, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *IdentityResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan IdentityModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	remote := plan.Remote.ValueString()
+	server, err := r.provider.InstanceServer(remote, "", "")
+	if err != nil {
+		resp.Diagnostics.Append(errors.NewInstanceServerError(err))
+		return
+	}
+
+	diags = r.updateIdentityGroups(ctx, server, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = r.SyncState(ctx, &resp.State, server, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *IdentityResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state IdentityModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	remote := state.Remote.ValueString()
+	server, err := r.provider.InstanceServer(remote, "", "")
+	if err != nil {
+		resp.Diagnostics.Append(errors.NewInstanceServerError(err))
+		return
+	}
+
+	// The identity is not removed, only its group membership is revoked.
+	authMethod := state.AuthenticationMethod.ValueString()
+	identityName := state.Name.ValueString()
+	_, etag, err := server.GetIdentity(authMethod, identityName)
+	if err != nil {
+		if !errors.IsNotFoundError(err) {
+			resp.Diagnostics.AddError(fmt.Sprintf("Failed to retrieve %s identity %q", authMethod, identityName), err.Error())
+		}
+
+		return
+	}
+
+	err = server.UpdateIdentity(authMethod, identityName, api.IdentityPut{Groups: []string{}}, etag)
+	if err != nil && !errors.IsNotFoundError(err) {
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to remove groups from %s identity %q", authMethod, identityName), err.Error())
+	}
+}
+
+// ImportState imports an identity using an import ID in the format
+// [remote:]authentication_method/name.
+func (r *IdentityResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	fields, err := parseIdentityImportID(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			fmt.Sprintf("Valid import format:\nimport incus_identity.<resource> [<remote>:]<authentication_method>/<name>\n\n%v", err),
+		)
+		return
+	}
+
+	for k, v := range fields {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(k), v)...)
+	}
+}
+
+// parseIdentityImportID parses an import ID in the format
+// [remote:]authentication_method/name. Identities are not project specific,
+// so unlike other import IDs, the ID has no project.
+func parseIdentityImportID(importID string) (map[string]string, error) {
+	result := make(map[string]string)
+
+	id, name, ok := strings.Cut(importID, "/")
+	if !ok {
+		return nil, fmt.Errorf("Import ID requires the name of the identity")
+	}
+
+	remote, authMethod, ok := strings.Cut(id, ":")
+	if ok {
+		if remote != "" {
+			result["remote"] = remote
+		}
+	} else {
+		authMethod = remote
+	}
+
+	if authMethod == "" {
+		return nil, fmt.Errorf("Import ID requires non-empty value for %q", "authentication_method")
+	}
+
+	if name == "" {
+		return nil, fmt.Errorf("Import ID requires non-empty value for %q", "name")
+	}
+
+	result["authentication_method"] = authMethod
+	result["name"] = name
+
+	return result, nil
+}
+
+// SyncState fetches the server's current state for an identity and updates
+// the provided model. It then applies this updated model as the new state
+// in Terraform.
+func (r *IdentityResource) SyncState(ctx context.Context, tfState *tfsdk.State, server incus.InstanceServer, m IdentityModel) diag.Diagnostics {
+	var respDiags diag.Diagnostics
+
+	authMethod := m.AuthenticationMethod.ValueString()
+	identityName := m.Name.ValueString()
+	identity, _, err := server.GetIdentity(authMethod, identityName)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			tfState.RemoveResource(ctx)
+			return nil
+		}
+
+		respDiags.AddError(fmt.Sprintf("Failed to retrieve %s identity %q", authMethod, identityName), err.Error())
+		return respDiags
+	}
+
+	groups, diags := ToGroupSetType(ctx, identity.Groups)
+	respDiags.Append(diags...)
+
+	if respDiags.HasError() {
+		return respDiags
+	}
+
+	// Name is kept as configured, since the identity can be referenced
+	// either by its name or by its identifier.
+	m.AuthenticationMethod = types.StringValue(identity.AuthenticationMethod)
+	m.Identifier = types.StringValue(identity.Identifier)
+	m.Type = types.StringValue(identity.Type)
+	m.Groups = groups
+
+	return tfState.Set(ctx, &m)
+}
+
+// updateIdentityGroups replaces the groups of an existing identity with
+// the groups from the given model.
+func (r *IdentityResource) updateIdentityGroups(ctx context.Context, server incus.InstanceServer, m IdentityModel) diag.Diagnostics {
+	var respDiags diag.Diagnostics
+
+	authMethod := m.AuthenticationMethod.ValueString()
+	identityName := m.Name.ValueString()
+	_, etag, err := server.GetIdentity(authMethod, identityName)
+	if err != nil {
+		respDiags.AddError(fmt.Sprintf("Failed to retrieve %s identity %q", authMethod, identityName), err.Error())
+		return respDiags
+	}
+
+	groups, diags := ToGroupList(ctx, m.Groups)
+	respDiags.Append(diags...)
+	if respDiags.HasError() {
+		return respDiags
+	}
+
+	err = server.UpdateIdentity(authMethod, identityName, api.IdentityPut{Groups: groups}, etag)
+	if err != nil {
+		respDiags.AddError(fmt.Sprintf("Failed to update %s identity %q", authMethod, identityName), err.Error())
+	}
+
+	return respDiags
+}
@@ -0,0 +1,67 @@
+package auth
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIdentity_parseIdentityImportID(t *testing.T) {
+	tests := []struct {
+		name     string
+		importID string
+		expected map[string]string
+		wantErr  bool
+	}{
+		{
+			name:     "method and name",
+			importID: "oidc/jdoe@example.com",
+			expected: map[string]string{"authentication_method": "oidc", "name": "jdoe@example.com"},
+		},
+		{
+			name:     "with remote",
+			importID: "local:tls/ci-runner",
+			expected: map[string]string{"remote": "local", "authentication_method": "tls", "name": "ci-runner"},
+		},
+		{
+			name:     "name with slash",
+			importID: "oidc/group/user",
+			expected: map[string]string{"authentication_method": "oidc", "name": "group/user"},
+		},
+		{
+			name:     "missing name",
+			importID: "oidc",
+			wantErr:  true,
+		},
+		{
+			name:     "empty name",
+			importID: "oidc/",
+			wantErr:  true,
+		},
+		{
+			name:     "empty method",
+			importID: "local:/jdoe",
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := parseIdentityImportID(tt.importID)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseIdentityImportID(%q) = %v, want error", tt.importID, actual)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("parseIdentityImportID(%q) failed: %v", tt.importID, err)
+			}
+
+			if !reflect.DeepEqual(actual, tt.expected) {
+				t.Fatalf("parseIdentityImportID(%q) = %v, want %v", tt.importID, actual, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,280 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	incus "github.com/lxc/incus/v7/client"
+	"github.com/lxc/incus/v7/shared/api"
+
+	"github.com/lxc/terraform-provider-incus/internal/common"
+	"github.com/lxc/terraform-provider-incus/internal/errors"
+	provider_config "github.com/lxc/terraform-provider-incus/internal/provider-config"
+)
+
+type IdentityProviderGroupModel struct {
+	Name   types.String `tfsdk:"name"`
+	Groups types.Set    `tfsdk:"groups"`
+	Remote types.String `tfsdk:"remote"`
+}
+
+type IdentityProviderGroupResource struct {
+	provider *provider_config.IncusProviderConfig
+}
+
+func NewIdentityProviderGroupResource() resource.Resource {
+	return &IdentityProviderGroupResource{}
+}
+
+func (r *IdentityProviderGroupResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_identity_provider_group", req.ProviderTypeName)
+}
+
+func (r *IdentityProviderGroupResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+
+			"groups": schema.SetAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					// Prevent empty values.
+					setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+
+			"remote": schema.StringAttribute{
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+func (r *IdentityProviderGroupResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	data := req.ProviderData
+	if data == nil {
+		return
+	}
+
+	provider, ok := data.(*provider_config.IncusProviderConfig)
+	if !ok {
+		resp.Diagnostics.Append(errors.NewProviderDataTypeError(req.ProviderData))
+		return
+	}
+
+	r.provider = provider
+}
+
+func (r *IdentityProviderGroupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan IdentityProviderGroupModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	remote := plan.Remote.ValueString()
+	server, err := r.provider.InstanceServer(remote, "", "")
+	if err != nil {
+		resp.Diagnostics.Append(errors.NewInstanceServerError(err))
+		return
+	}
+
+	groups, diags := ToGroupList(ctx, plan.Groups)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	idpGroup := api.IdentityProviderGroup{
+		Name:   plan.Name.ValueString(),
+		Groups: groups,
+	}
+
+	err = server.CreateIdentityProviderGroup(idpGroup)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to create identity provider group %q", idpGroup.Name), err.Error())
+		return
+	}
+
+	diags = r.SyncState(ctx, &resp.State, server, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *IdentityProviderGroupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state IdentityProviderGroupModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	remote := state.Remote.ValueString()
+	server, err := r.provider.InstanceServer(remote, "", "")
+	if err != nil {
+		resp.Diagnostics.Append(errors.NewInstanceServerError(err))
+		return
+	}
+
+	diags = r.SyncState(ctx, &resp.State, server, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *IdentityProviderGroupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan IdentityProviderGroupModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	remote := plan.Remote.ValueString()
+	server, err := r.provider.InstanceServer(remote, "", "")
+	if err != nil {
+		resp.Diagnostics.Append(errors.NewInstanceServerError(err))
+		return
+	}
+
+	idpGroupName := plan.Name.ValueString()
+	_, etag, err := server.GetIdentityProviderGroup(idpGroupName)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to retrieve identity provider group %q", idpGroupName), err.Error())
+		return
+	}
+
+	groups, diags := ToGroupList(ctx, plan.Groups)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	idpGroupReq := api.IdentityProviderGroupPut{
+		Groups: groups,
+	}
+
+	err = server.UpdateIdentityProviderGroup(idpGroupName, idpGroupReq, etag)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to update identity provider group %q", idpGroupName), err.Error())
+		return
+	}
+
+	diags = r.SyncState(ctx, &resp.State, server, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *IdentityProviderGroupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state IdentityProviderGroupModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	remote := state.Remote.ValueString()
+	server, err := r.provider.InstanceServer(remote, "", "")
+	if err != nil {
+		resp.Diagnostics.Append(errors.NewInstanceServerError(err))
+		return
+	}
+
+	idpGroupName := state.Name.ValueString()
+	err = server.DeleteIdentityProviderGroup(idpGroupName)
+	if err != nil && !errors.IsNotFoundError(err) {
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to remove identity provider group %q", idpGroupName), err.Error())
+	}
+}
+
+func (r *IdentityProviderGroupResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	meta := common.ImportMetadata{
+		ResourceName:   "identity_provider_group",
+		RequiredFields: []string{"name"},
+	}
+
+	fields, diag := meta.ParseImportID(req.ID)
+	if diag != nil {
+		resp.Diagnostics.Append(diag)
+		return
+	}
+
+	for k, v := range fields {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(k), v)...)
+	}
+}
+
+// SyncState fetches the server's current state for an identity provider
+// group and updates the provided model. It then applies this updated model
+// as the new state in Terraform.
+func (r *IdentityProviderGroupResource) SyncState(ctx context.Context, tfState *tfsdk.State, server incus.InstanceServer, m IdentityProviderGroupModel) diag.Diagnostics {
+	var respDiags diag.Diagnostics
+
+	idpGroupName := m.Name.ValueString()
+	idpGroup, _, err := server.GetIdentityProviderGroup(idpGroupName)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			tfState.RemoveResource(ctx)
+			return nil
+		}
+
+		respDiags.AddError(fmt.Sprintf("Failed to retrieve identity provider group %q", idpGroupName), err.Error())
+		return respDiags
+	}
+
+	groups, diags := ToGroupSetType(ctx, idpGroup.Groups)
+	respDiags.Append(diags...)
+
+	if respDiags.HasError() {
+		return respDiags
+	}
+
+	m.Name = types.StringValue(idpGroup.Name)
+	m.Groups = groups
+
+	return tfState.Set(ctx, &m)
+}
+
+// ToGroupList converts auth group names of type types.Set into []string.
+func ToGroupList(ctx context.Context, groupSet types.Set) ([]string, diag.Diagnostics) {
+	groups := make([]string, 0, len(groupSet.Elements()))
+	diags := groupSet.ElementsAs(ctx, &groups, false)
+
+	return groups, diags
+}
+
+// ToGroupSetType converts []string into auth group names of type types.Set.
+func ToGroupSetType(ctx context.Context, groups []string) (types.Set, diag.Diagnostics) {
+	nilSet := types.SetNull(types.StringType)
+
+	if len(groups) == 0 {
+		return nilSet, nil
+	}
+
+	return types.SetValueFrom(ctx, types.StringType, groups)
+}
@@ -0,0 +1,133 @@
+package auth_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	petname "github.com/dustinkirkland/golang-petname"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/lxc/terraform-provider-incus/internal/acctest"
+)
+
+func TestAccIdentityProviderGroup_basic(t *testing.T) {
+	idpGroupName := petname.Generate(2, "-")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			acctest.PreCheck(t)
+			acctest.PreCheckAPIExtensions(t, "access_management")
+		},
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccIdentityProviderGroup_basic(idpGroupName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_identity_provider_group.idp_group1", "name", idpGroupName),
+					resource.TestCheckNoResourceAttr("incus_identity_provider_group.idp_group1", "groups"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccIdentityProviderGroup_groups(t *testing.T) {
+	idpGroupName := petname.Generate(2, "-")
+	groupName1 := petname.Generate(2, "-")
+	groupName2 := petname.Generate(2, "-")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			acctest.PreCheck(t)
+			acctest.PreCheckAPIExtensions(t, "access_management")
+		},
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccIdentityProviderGroup_groups(idpGroupName, groupName1, groupName2, "incus_auth_group.group1.name"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_identity_provider_group.idp_group1", "name", idpGroupName),
+					resource.TestCheckResourceAttr("incus_identity_provider_group.idp_group1", "groups.#", "1"),
+					resource.TestCheckTypeSetElemAttr("incus_identity_provider_group.idp_group1", "groups.*", groupName1),
+				),
+			},
+			{
+				Config: testAccIdentityProviderGroup_groups(idpGroupName, groupName1, groupName2, "incus_auth_group.group1.name, incus_auth_group.group2.name"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_identity_provider_group.idp_group1", "groups.#", "2"),
+					resource.TestCheckTypeSetElemAttr("incus_identity_provider_group.idp_group1", "groups.*", groupName1),
+					resource.TestCheckTypeSetElemAttr("incus_identity_provider_group.idp_group1", "groups.*", groupName2),
+				),
+			},
+		},
+	})
+}
+
+func TestAccIdentityProviderGroup_emptyGroups(t *testing.T) {
+	idpGroupName := petname.Generate(2, "-")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			acctest.PreCheck(t)
+			acctest.PreCheckAPIExtensions(t, "access_management")
+		},
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccIdentityProviderGroup_groups(idpGroupName, "group1", "group2", ""),
+				ExpectError: regexp.MustCompile(`set must contain at least 1 elements`),
+			},
+		},
+	})
+}
+
+func TestAccIdentityProviderGroup_importBasic(t *testing.T) {
+	idpGroupName := petname.Generate(2, "-")
+	resourceName := "incus_identity_provider_group.idp_group1"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			acctest.PreCheck(t)
+			acctest.PreCheckAPIExtensions(t, "access_management")
+		},
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccIdentityProviderGroup_basic(idpGroupName),
+			},
+			{
+				ResourceName:                         resourceName,
+				ImportStateId:                        idpGroupName,
+				ImportStateVerifyIdentifierAttribute: "name",
+				ImportState:                          true,
+				ImportStateVerify:                    true,
+			},
+		},
+	})
+}
+
+func testAccIdentityProviderGroup_basic(name string) string {
+	return fmt.Sprintf(`
+resource "incus_identity_provider_group" "idp_group1" {
+  name = "%s"
+}
+`, name)
+}
+
+func testAccIdentityProviderGroup_groups(name string, groupName1 string, groupName2 string, groups string) string {
+	return fmt.Sprintf(`
+resource "incus_auth_group" "group1" {
+  name = "%s"
+}
+
+resource "incus_auth_group" "group2" {
+  name = "%s"
+}
+
+resource "incus_identity_provider_group" "idp_group1" {
+  name   = "%s"
+  groups = [%s]
+}
+`, groupName1, groupName2, name, groups)
+}
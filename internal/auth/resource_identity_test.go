@@ -0,0 +1,133 @@
+package auth_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	petname "github.com/dustinkirkland/golang-petname"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	"github.com/lxc/terraform-provider-incus/internal/acctest"
+)
+
+func TestAccIdentity_notFound(t *testing.T) {
+	identityName := petname.Generate(2, "-")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			acctest.PreCheck(t)
+			acctest.PreCheckAPIExtensions(t, "access_management")
+		},
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccIdentity_basic(identityName),
+				ExpectError: regexp.MustCompile(fmt.Sprintf(`Failed to retrieve oidc identity %q`, identityName)),
+			},
+		},
+	})
+}
+
+func TestAccIdentity_importBasic(t *testing.T) {
+	resourceName := "incus_identity.identity1"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			acctest.PreCheck(t)
+			acctest.PreCheckAPIExtensions(t, "access_management")
+		},
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// The identity used by the tests is always present.
+				Config: testAccIdentity_tls(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "authentication_method", "tls"),
+					resource.TestCheckResourceAttrSet(resourceName, "identifier"),
+				),
+			},
+			{
+				ResourceName: resourceName,
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs, ok := s.RootModule().Resources[resourceName]
+					if !ok {
+						return "", fmt.Errorf("Resource %q not found", resourceName)
+					}
+
+					return fmt.Sprintf("tls/%s", rs.Primary.Attributes["name"]), nil
+				},
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "name",
+			},
+		},
+	})
+}
+
+func TestAccIdentity_invalidAuthenticationMethod(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			acctest.PreCheck(t)
+		},
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "incus_identity" "identity1" {
+  authentication_method = "invalid"
+  name                  = "user"
+}
+`,
+				ExpectError: regexp.MustCompile(`Attribute authentication_method value must be one of`),
+			},
+		},
+	})
+}
+
+func TestAccIdentitiesDataSource_tls(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			acctest.PreCheck(t)
+			acctest.PreCheckAPIExtensions(t, "access_management")
+		},
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "incus_identities" "tls" {
+  authentication_method = "tls"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					// The identity used by the tests is always present.
+					resource.TestMatchResourceAttr("data.incus_identities.tls", "identities.#", regexp.MustCompile(`^[1-9][0-9]*$`)),
+					resource.TestCheckResourceAttr("data.incus_identities.tls", "identities.0.authentication_method", "tls"),
+				),
+			},
+		},
+	})
+}
+
+func testAccIdentity_basic(name string) string {
+	return fmt.Sprintf(`
+resource "incus_identity" "identity1" {
+  authentication_method = "oidc"
+  name                  = "%s"
+}
+`, name)
+}
+
+func testAccIdentity_tls() string {
+	return `
+data "incus_identities" "tls" {
+  authentication_method = "tls"
+}
+
+resource "incus_identity" "identity1" {
+  authentication_method = "tls"
+  name                  = data.incus_identities.tls.identities[0].name
+}
+`
+}
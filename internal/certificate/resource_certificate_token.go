@@ -0,0 +1,307 @@
+package certificate
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	incus "github.com/lxc/incus/v7/client"
+	"github.com/lxc/incus/v7/shared/api"
+
+	"github.com/lxc/terraform-provider-incus/internal/common"
+	"github.com/lxc/terraform-provider-incus/internal/errors"
+	provider_config "github.com/lxc/terraform-provider-incus/internal/provider-config"
+)
+
+type CertificateTokenModel struct {
+	Name       types.String `tfsdk:"name"`
+	Projects   types.Set    `tfsdk:"projects"`
+	Restricted types.Bool   `tfsdk:"restricted"`
+	ExpiresAt  types.String `tfsdk:"expires_at"`
+	Remote     types.String `tfsdk:"remote"`
+
+	// Computed.
+	Token types.String `tfsdk:"token"`
+}
+
+type CertificateTokenResource struct {
+	provider *provider_config.IncusProviderConfig
+}
+
+func NewCertificateTokenResource() resource.Resource {
+	return &CertificateTokenResource{}
+}
+
+func (r *CertificateTokenResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_certificate_token", req.ProviderTypeName)
+}
+
+func (r *CertificateTokenResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+
+			"projects": schema.SetAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.Set{
+					// Prevent empty values.
+					setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+
+			"restricted": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+
+			"expires_at": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					common.TimestampValidator{},
+				},
+			},
+
+			"remote": schema.StringAttribute{
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+
+			// Computed attributes
+
+			"token": schema.StringAttribute{
+				Computed:  true,
+				Sensitive: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *CertificateTokenResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	data := req.ProviderData
+	if data == nil {
+		return
+	}
+
+	provider, ok := data.(*provider_config.IncusProviderConfig)
+	if !ok {
+		resp.Diagnostics.Append(errors.NewProviderDataTypeError(req.ProviderData))
+		return
+	}
+
+	r.provider = provider
+}
+
+func (r *CertificateTokenResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// If resource is being created or destroyed, req.State or req.Plan will be null.
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var state CertificateTokenModel
+	var config CertificateTokenModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+
+	diags = req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	expiresAt, err := common.ToTimestamp(state.ExpiresAt)
+	if err != nil || expiresAt == nil || time.Now().Before(*expiresAt) {
+		return
+	}
+
+	// The token has expired, therefore replace it with a new one. The
+	// expired token is revoked when the resource is destroyed.
+	if config.ExpiresAt.IsNull() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("expires_at"), types.StringUnknown())...)
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("token"), types.StringUnknown())...)
+	resp.RequiresReplace = append(resp.RequiresReplace, path.Root("token"))
+}
+
+func (r *CertificateTokenResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan CertificateTokenModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	remote := plan.Remote.ValueString()
+	server, err := r.provider.InstanceServer(remote, "", "")
+	if err != nil {
+		resp.Diagnostics.Append(errors.NewInstanceServerError(err))
+		return
+	}
+
+	projects, diags := toProjectList(ctx, plan.Projects)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	name := plan.Name.ValueString()
+
+	expiresAt, err := common.ToTimestamp(plan.ExpiresAt)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to parse expiry of trust token %q", name), err.Error())
+		return
+	}
+
+	if expiresAt != nil && !expiresAt.After(time.Now()) {
+		resp.Diagnostics.AddError(fmt.Sprintf("Invalid expiry of trust token %q", name), fmt.Sprintf("The expiry %q is not in the future.", plan.ExpiresAt.ValueString()))
+		return
+	}
+
+	tokenReq := api.CertificatesPost{
+		CertificatePut: api.CertificatePut{
+			Name:       name,
+			Type:       "client",
+			Restricted: plan.Restricted.ValueBool(),
+			Projects:   projects,
+		},
+		Token: true,
+	}
+
+	op, err := server.CreateCertificateToken(tokenReq)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to create trust token %q", name), err.Error())
+		return
+	}
+
+	opAPI := op.Get()
+	token, err := opAPI.ToCertificateAddToken()
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to retrieve trust token %q", name), err.Error())
+		return
+	}
+
+	plan.Token = types.StringValue(token.String())
+
+	// The server does not accept an expiry for a single token, so a
+	// configured expiry is enforced by the provider, which replaces the
+	// token once it has expired.
+	if expiresAt == nil {
+		plan.ExpiresAt = common.ToTimestampType(&token.ExpiresAt, plan.ExpiresAt)
+	} else if !token.ExpiresAt.IsZero() && token.ExpiresAt.Before(*expiresAt) {
+		resp.Diagnostics.AddWarning(
+			fmt.Sprintf("Trust token %q expires earlier than configured", name),
+			fmt.Sprintf("The server expires the token at %s.", token.ExpiresAt.UTC().Format(time.RFC3339)),
+		)
+	}
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *CertificateTokenResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// The token is only available when it is created. Once it is used to
+	// add a certificate, the server no longer reports it, but the resource
+	// is kept to avoid issuing a new token on every apply. Expired tokens
+	// are replaced when planning.
+}
+
+func (r *CertificateTokenResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// All attributes require replacement.
+}
+
+func (r *CertificateTokenResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state CertificateTokenModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	remote := state.Remote.ValueString()
+	server, err := r.provider.InstanceServer(remote, "", "")
+	if err != nil {
+		resp.Diagnostics.Append(errors.NewInstanceServerError(err))
+		return
+	}
+
+	name := state.Name.ValueString()
+	err = revokeCertificateToken(server, name)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to revoke trust token %q", name), err.Error())
+	}
+}
+
+// revokeCertificateToken cancels pending trust token operations issued
+// for the client with the given name. Tokens that were already used or
+// have expired are ignored.
+func revokeCertificateToken(server incus.InstanceServer, name string) error {
+	ops, err := server.GetOperations()
+	if err != nil {
+		return err
+	}
+
+	for _, op := range ops {
+		if op.Class != api.OperationClassToken {
+			continue
+		}
+
+		token, err := op.ToCertificateAddToken()
+		if err != nil {
+			// Not a trust token.
+			continue
+		}
+
+		if token.ClientName != name {
+			continue
+		}
+
+		err = server.DeleteOperation(op.ID)
+		if err != nil && !errors.IsNotFoundError(err) {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,149 @@
+package certificate_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	petname "github.com/dustinkirkland/golang-petname"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/lxc/terraform-provider-incus/internal/acctest"
+)
+
+func TestAccCertificateToken_basic(t *testing.T) {
+	tokenName := petname.Generate(2, "-")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			acctest.PreCheck(t)
+		},
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCertificateToken_basic(tokenName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_certificate_token.token1", "name", tokenName),
+					resource.TestCheckResourceAttr("incus_certificate_token.token1", "restricted", "false"),
+					resource.TestCheckResourceAttrSet("incus_certificate_token.token1", "token"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCertificateToken_withProject(t *testing.T) {
+	tokenName := petname.Generate(2, "-")
+	projectName := petname.Generate(1, "")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			acctest.PreCheck(t)
+		},
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCertificateToken_withProject(tokenName, projectName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_certificate_token.token1", "name", tokenName),
+					resource.TestCheckResourceAttr("incus_certificate_token.token1", "restricted", "true"),
+					resource.TestCheckResourceAttr("incus_certificate_token.token1", "projects.#", "1"),
+					resource.TestCheckResourceAttr("incus_certificate_token.token1", "projects.0", projectName),
+					resource.TestCheckResourceAttrSet("incus_certificate_token.token1", "token"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCertificateToken_expiresAt(t *testing.T) {
+	tokenName := petname.Generate(2, "-")
+	expiresAt := time.Now().Add(24 * time.Hour).UTC().Format(time.RFC3339)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			acctest.PreCheck(t)
+		},
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccCertificateToken_expiresAt(tokenName, "tomorrow"),
+				ExpectError: regexp.MustCompile(`Invalid timestamp`),
+			},
+			{
+				Config:      testAccCertificateToken_expiresAt(tokenName, "2000-01-01T00:00:00Z"),
+				ExpectError: regexp.MustCompile(`Invalid expiry of trust token`),
+			},
+			{
+				Config: testAccCertificateToken_expiresAt(tokenName, expiresAt),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_certificate_token.token1", "name", tokenName),
+					resource.TestCheckResourceAttr("incus_certificate_token.token1", "expires_at", expiresAt),
+					resource.TestCheckResourceAttrSet("incus_certificate_token.token1", "token"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCertificateToken_expired(t *testing.T) {
+	tokenName := petname.Generate(2, "-")
+	expiresAt := time.Now().Add(10 * time.Second).UTC().Format(time.RFC3339)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			acctest.PreCheck(t)
+		},
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCertificateToken_expiresAt(tokenName, expiresAt),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_certificate_token.token1", "expires_at", expiresAt),
+					resource.TestCheckResourceAttrSet("incus_certificate_token.token1", "token"),
+				),
+			},
+			{
+				// Once expired, the token is planned for replacement.
+				PreConfig: func() {
+					time.Sleep(15 * time.Second)
+				},
+				Config:             testAccCertificateToken_expiresAt(tokenName, expiresAt),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func testAccCertificateToken_basic(name string) string {
+	return fmt.Sprintf(`
+resource "incus_certificate_token" "token1" {
+  name = "%s"
+}
+`, name)
+}
+
+func testAccCertificateToken_withProject(name string, projectName string) string {
+	return fmt.Sprintf(`
+resource "incus_project" "project1" {
+  name = "%s"
+}
+
+resource "incus_certificate_token" "token1" {
+  name       = "%s"
+  restricted = true
+  projects   = [incus_project.project1.name]
+}
+`, projectName, name)
+}
+
+func testAccCertificateToken_expiresAt(name string, expiresAt string) string {
+	return fmt.Sprintf(`
+resource "incus_certificate_token" "token1" {
+  name       = "%s"
+  expires_at = "%s"
+}
+`, name, expiresAt)
+}
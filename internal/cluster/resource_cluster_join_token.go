@@ -0,0 +1,202 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	incus "github.com/lxc/incus/v7/client"
+	"github.com/lxc/incus/v7/shared/api"
+
+	"github.com/lxc/terraform-provider-incus/internal/errors"
+	provider_config "github.com/lxc/terraform-provider-incus/internal/provider-config"
+)
+
+type ClusterJoinTokenModel struct {
+	Name   types.String `tfsdk:"name"`
+	Remote types.String `tfsdk:"remote"`
+
+	// Computed.
+	Token     types.String `tfsdk:"token"`
+	ExpiresAt types.String `tfsdk:"expires_at"`
+}
+
+type ClusterJoinTokenResource struct {
+	provider *provider_config.IncusProviderConfig
+}
+
+func NewClusterJoinTokenResource() resource.Resource {
+	return &ClusterJoinTokenResource{}
+}
+
+func (r *ClusterJoinTokenResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_cluster_join_token", req.ProviderTypeName)
+}
+
+func (r *ClusterJoinTokenResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+
+			"remote": schema.StringAttribute{
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+
+			// Computed.
+
+			"token": schema.StringAttribute{
+				Computed:  true,
+				Sensitive: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"expires_at": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *ClusterJoinTokenResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	data := req.ProviderData
+	if data == nil {
+		return
+	}
+
+	provider, ok := data.(*provider_config.IncusProviderConfig)
+	if !ok {
+		resp.Diagnostics.Append(errors.NewProviderDataTypeError(req.ProviderData))
+		return
+	}
+
+	r.provider = provider
+}
+
+func (r *ClusterJoinTokenResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan ClusterJoinTokenModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	remote := plan.Remote.ValueString()
+	server, err := r.provider.InstanceServer(remote, "", "")
+	if err != nil {
+		resp.Diagnostics.Append(errors.NewInstanceServerError(err))
+		return
+	}
+
+	memberName := plan.Name.ValueString()
+	op, err := server.CreateClusterMember(api.ClusterMembersPost{ServerName: memberName})
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to create join token for cluster member %q", memberName), err.Error())
+		return
+	}
+
+	opAPI := op.Get()
+	joinToken, err := opAPI.ToClusterJoinToken()
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to retrieve join token for cluster member %q", memberName), err.Error())
+		return
+	}
+
+	plan.Token = types.StringValue(joinToken.String())
+	plan.ExpiresAt = types.StringNull()
+	if !joinToken.ExpiresAt.IsZero() {
+		plan.ExpiresAt = types.StringValue(joinToken.ExpiresAt.UTC().Format(time.RFC3339))
+	}
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *ClusterJoinTokenResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// The token is only available when it is created. Once it is used to
+	// join the cluster or expires, the server no longer reports it, but the
+	// resource is kept to avoid issuing a new token on every apply.
+}
+
+func (r *ClusterJoinTokenResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// All attributes require replacement.
+}
+
+func (r *ClusterJoinTokenResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state ClusterJoinTokenModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	remote := state.Remote.ValueString()
+	server, err := r.provider.InstanceServer(remote, "", "")
+	if err != nil {
+		resp.Diagnostics.Append(errors.NewInstanceServerError(err))
+		return
+	}
+
+	memberName := state.Name.ValueString()
+	err = revokeClusterJoinToken(server, memberName)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to revoke join token for cluster member %q", memberName), err.Error())
+	}
+}
+
+// revokeClusterJoinToken cancels pending join token operations for the
+// cluster member with the given name. Tokens that were already used or
+// have expired are ignored.
+func revokeClusterJoinToken(server incus.InstanceServer, memberName string) error {
+	ops, err := server.GetOperations()
+	if err != nil {
+		return err
+	}
+
+	for _, op := range ops {
+		if op.Class != api.OperationClassToken {
+			continue
+		}
+
+		joinToken, err := op.ToClusterJoinToken()
+		if err != nil {
+			// Not a cluster join token.
+			continue
+		}
+
+		if joinToken.ServerName != memberName {
+			continue
+		}
+
+		err = server.DeleteOperation(op.ID)
+		if err != nil && !errors.IsNotFoundError(err) {
+			return err
+		}
+	}
+
+	return nil
+}
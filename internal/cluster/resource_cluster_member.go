@@ -0,0 +1,458 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	incus "github.com/lxc/incus/v7/client"
+	"github.com/lxc/incus/v7/shared/api"
+
+	"github.com/lxc/terraform-provider-incus/internal/common"
+	"github.com/lxc/terraform-provider-incus/internal/errors"
+	provider_config "github.com/lxc/terraform-provider-incus/internal/provider-config"
+	"github.com/lxc/terraform-provider-incus/internal/utils"
+)
+
+// clusterMemberAutomaticRoles are roles assigned by Incus itself. They are
+// reported by the server, but cannot be set by the user.
+var clusterMemberAutomaticRoles = []string{
+	"database",
+	"database-leader",
+	"database-standby",
+}
+
+type ClusterMemberModel struct {
+	Name            types.String `tfsdk:"name"`
+	Description     types.String `tfsdk:"description"`
+	Config          types.Map    `tfsdk:"config"`
+	FailureDomain   types.String `tfsdk:"failure_domain"`
+	Roles           types.Set    `tfsdk:"roles"`
+	Groups          types.Set    `tfsdk:"groups"`
+	Evacuated       types.Bool   `tfsdk:"evacuated"`
+	RemoveOnDestroy types.Bool   `tfsdk:"remove_on_destroy"`
+	Force           types.Bool   `tfsdk:"force"`
+	Remote          types.String `tfsdk:"remote"`
+
+	// Computed.
+	URL          types.String `tfsdk:"url"`
+	Status       types.String `tfsdk:"status"`
+	Architecture types.String `tfsdk:"architecture"`
+}
+
+type ClusterMemberResource struct {
+	provider *provider_config.IncusProviderConfig
+}
+
+func NewClusterMemberResource() resource.Resource {
+	return &ClusterMemberResource{}
+}
+
+func (r *ClusterMemberResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_cluster_member", req.ProviderTypeName)
+}
+
+func (r *ClusterMemberResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+
+			"description": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(""),
+			},
+
+			"config": schema.MapAttribute{
+				Optional:    true,
+				Computed:    true,
+				ElementType: types.StringType,
+				Default:     mapdefault.StaticValue(types.MapValueMust(types.StringType, map[string]attr.Value{})),
+			},
+
+			"failure_domain": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+
+			"roles": schema.SetAttribute{
+				Optional:    true,
+				Computed:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(stringvalidator.NoneOf(clusterMemberAutomaticRoles...)),
+				},
+			},
+
+			"groups": schema.SetAttribute{
+				Optional:    true,
+				Computed:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+
+			"evacuated": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+
+			"remove_on_destroy": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
+			},
+
+			"force": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+
+			"remote": schema.StringAttribute{
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+
+			// Computed.
+
+			"url": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"status": schema.StringAttribute{
+				Computed: true,
+			},
+
+			"architecture": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *ClusterMemberResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	data := req.ProviderData
+	if data == nil {
+		return
+	}
+
+	provider, ok := data.(*provider_config.IncusProviderConfig)
+	if !ok {
+		resp.Diagnostics.Append(errors.NewProviderDataTypeError(req.ProviderData))
+		return
+	}
+
+	r.provider = provider
+}
+
+func (r *ClusterMemberResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan ClusterMemberModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	remote := plan.Remote.ValueString()
+	server, err := r.provider.InstanceServer(remote, "", "")
+	if err != nil {
+		resp.Diagnostics.Append(errors.NewInstanceServerError(err))
+		return
+	}
+
+	// A member joins the cluster on its own, therefore the resource only
+	// takes over management of an already existing member. Joining new
+	// members is not supported.
+	diags = r.updateClusterMember(ctx, server, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = r.SyncState(ctx, &resp.State, server, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *ClusterMemberResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state ClusterMemberModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	remote := state.Remote.ValueString()
+	server, err := r.provider.InstanceServer(remote, "", "")
+	if err != nil {
+		resp.Diagnostics.Append(errors.NewInstanceServerError(err))
+		return
+	}
+
+	diags = r.SyncState(ctx, &resp.State, server, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *ClusterMemberResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan ClusterMemberModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	remote := plan.Remote.ValueString()
+	server, err := r.provider.InstanceServer(remote, "", "")
+	if err != nil {
+		resp.Diagnostics.Append(errors.NewInstanceServerError(err))
+		return
+	}
+
+	diags = r.updateClusterMember(ctx, server, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = r.SyncState(ctx, &resp.State, server, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *ClusterMemberResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state ClusterMemberModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// If opted out, the member stays in the cluster and is only removed
+	// from the Terraform state.
+	if !state.RemoveOnDestroy.ValueBool() {
+		return
+	}
+
+	remote := state.Remote.ValueString()
+	server, err := r.provider.InstanceServer(remote, "", "")
+	if err != nil {
+		resp.Diagnostics.Append(errors.NewInstanceServerError(err))
+		return
+	}
+
+	memberName := state.Name.ValueString()
+	err = server.DeleteClusterMember(memberName, state.Force.ValueBool())
+	if err != nil && !errors.IsNotFoundError(err) {
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to remove cluster member %q", memberName), err.Error())
+	}
+}
+
+func (r *ClusterMemberResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	meta := common.ImportMetadata{
+		ResourceName:   "cluster_member",
+		RequiredFields: []string{"name"},
+	}
+
+	fields, diag := meta.ParseImportID(req.ID)
+	if diag != nil {
+		resp.Diagnostics.Append(diag)
+		return
+	}
+
+	for k, v := range fields {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(k), v)...)
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("remove_on_destroy"), true)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("force"), false)...)
+}
+
+// updateClusterMember applies the configuration from the provided model to
+// an existing cluster member. Afterwards, the member is evacuated or restored
+// if its current state does not match the desired one.
+func (r *ClusterMemberResource) updateClusterMember(ctx context.Context, server incus.InstanceServer, m ClusterMemberModel) diag.Diagnostics {
+	var respDiags diag.Diagnostics
+
+	memberName := m.Name.ValueString()
+	member, etag, err := server.GetClusterMember(memberName)
+	if err != nil {
+		respDiags.AddError(fmt.Sprintf("Failed to retrieve cluster member %q", memberName), err.Error())
+		return respDiags
+	}
+
+	config, diags := common.ToConfigMap(ctx, m.Config)
+	respDiags.Append(diags...)
+
+	// Keep the current roles, groups and failure domain unless they
+	// are configured.
+	roles := userClusterMemberRoles(member.Roles)
+	if !m.Roles.IsNull() && !m.Roles.IsUnknown() {
+		roles, diags = ToMemberSet(ctx, m.Roles)
+		respDiags.Append(diags...)
+	}
+
+	groups := member.Groups
+	if !m.Groups.IsNull() && !m.Groups.IsUnknown() {
+		groups, diags = ToMemberSet(ctx, m.Groups)
+		respDiags.Append(diags...)
+	}
+
+	if respDiags.HasError() {
+		return respDiags
+	}
+
+	failureDomain := member.FailureDomain
+	if !m.FailureDomain.IsNull() && !m.FailureDomain.IsUnknown() {
+		failureDomain = m.FailureDomain.ValueString()
+	}
+
+	memberReq := api.ClusterMemberPut{
+		Description:   m.Description.ValueString(),
+		Config:        config,
+		FailureDomain: failureDomain,
+		Roles:         roles,
+		Groups:        groups,
+	}
+
+	err = server.UpdateClusterMember(memberName, memberReq, etag)
+	if err != nil {
+		respDiags.AddError(fmt.Sprintf("Failed to update cluster member %q", memberName), err.Error())
+		return respDiags
+	}
+
+	evacuated := isClusterMemberEvacuated(*member)
+	if m.Evacuated.IsUnknown() || m.Evacuated.ValueBool() == evacuated {
+		return respDiags
+	}
+
+	action := "evacuate"
+	if evacuated {
+		action = "restore"
+	}
+
+	op, err := server.UpdateClusterMemberState(memberName, api.ClusterMemberStatePost{Action: action})
+	if err == nil {
+		err = op.WaitContext(ctx)
+	}
+
+	if err != nil {
+		respDiags.AddError(fmt.Sprintf("Failed to %s cluster member %q", action, memberName), err.Error())
+	}
+
+	return respDiags
+}
+
+// SyncState fetches the server's current state for a cluster member and
+// updates the provided model. It then applies this updated model as the new
+// state in Terraform.
+func (r *ClusterMemberResource) SyncState(ctx context.Context, tfState *tfsdk.State, server incus.InstanceServer, m ClusterMemberModel) diag.Diagnostics {
+	var respDiags diag.Diagnostics
+
+	memberName := m.Name.ValueString()
+	member, _, err := server.GetClusterMember(memberName)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			tfState.RemoveResource(ctx)
+			return nil
+		}
+
+		respDiags.AddError(fmt.Sprintf("Failed to retrieve cluster member %q", memberName), err.Error())
+		return respDiags
+	}
+
+	config, diags := common.ToConfigMapType(ctx, common.ToNullableConfig(member.Config), m.Config)
+	respDiags.Append(diags...)
+
+	roles, diags := types.SetValueFrom(ctx, types.StringType, userClusterMemberRoles(member.Roles))
+	respDiags.Append(diags...)
+
+	groups, diags := types.SetValueFrom(ctx, types.StringType, member.Groups)
+	respDiags.Append(diags...)
+
+	if respDiags.HasError() {
+		return respDiags
+	}
+
+	m.Name = types.StringValue(member.ServerName)
+	m.Description = types.StringValue(member.Description)
+	m.Config = config
+	m.FailureDomain = types.StringValue(member.FailureDomain)
+	m.Roles = roles
+	m.Groups = groups
+	m.Evacuated = types.BoolValue(isClusterMemberEvacuated(*member))
+	m.URL = types.StringValue(member.URL)
+	m.Status = types.StringValue(member.Status)
+	m.Architecture = types.StringValue(member.Architecture)
+
+	return tfState.Set(ctx, &m)
+}
+
+// userClusterMemberRoles returns the provided roles without the ones that
+// are assigned automatically by Incus.
+func userClusterMemberRoles(roles []string) []string {
+	userRoles := make([]string, 0, len(roles))
+	for _, role := range roles {
+		if utils.ValueInSlice(role, clusterMemberAutomaticRoles) {
+			continue
+		}
+
+		userRoles = append(userRoles, role)
+	}
+
+	return userRoles
+}
+
+// isClusterMemberEvacuated determines whether the cluster member is
+// evacuated.
+func isClusterMemberEvacuated(member api.ClusterMember) bool {
+	return member.Status == "Evacuated"
+}
@@ -0,0 +1,219 @@
+package cluster_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	petname "github.com/dustinkirkland/golang-petname"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/lxc/terraform-provider-incus/internal/acctest"
+)
+
+func TestAccClusterMember_basic(t *testing.T) {
+	groupName := petname.Generate(2, "-")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			acctest.PreCheck(t)
+			acctest.PreCheckClustering(t)
+		},
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccClusterMember_basic(groupName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_cluster_member.member1", "description", "Managed by Terraform"),
+					resource.TestCheckResourceAttr("incus_cluster_member.member1", "config.%", "1"),
+					resource.TestCheckResourceAttr("incus_cluster_member.member1", "config.scheduler.instance", "manual"),
+					resource.TestCheckResourceAttr("incus_cluster_member.member1", "failure_domain", "rack1"),
+					resource.TestCheckResourceAttr("incus_cluster_member.member1", "groups.#", "2"),
+					resource.TestCheckTypeSetElemAttr("incus_cluster_member.member1", "groups.*", "default"),
+					resource.TestCheckTypeSetElemAttr("incus_cluster_member.member1", "groups.*", groupName),
+					resource.TestCheckResourceAttr("incus_cluster_member.member1", "evacuated", "false"),
+					resource.TestCheckResourceAttr("incus_cluster_member.member1", "remove_on_destroy", "false"),
+					resource.TestCheckResourceAttr("incus_cluster_member.member1", "status", "Online"),
+					resource.TestCheckResourceAttrSet("incus_cluster_member.member1", "url"),
+					resource.TestCheckResourceAttrSet("incus_cluster_member.member1", "architecture"),
+				),
+			},
+			{
+				// Restore the defaults, as the member stays in the cluster.
+				Config: testAccClusterMember_defaults(groupName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_cluster_member.member1", "description", ""),
+					resource.TestCheckResourceAttr("incus_cluster_member.member1", "config.%", "0"),
+					resource.TestCheckResourceAttr("incus_cluster_member.member1", "failure_domain", "default"),
+					resource.TestCheckResourceAttr("incus_cluster_member.member1", "groups.#", "1"),
+					resource.TestCheckTypeSetElemAttr("incus_cluster_member.member1", "groups.*", "default"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccClusterMember_evacuate(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			acctest.PreCheck(t)
+			acctest.PreCheckClustering(t)
+		},
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccClusterMember_evacuated(true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_cluster_member.member1", "evacuated", "true"),
+					resource.TestCheckResourceAttr("incus_cluster_member.member1", "status", "Evacuated"),
+				),
+			},
+			{
+				Config: testAccClusterMember_evacuated(false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_cluster_member.member1", "evacuated", "false"),
+					resource.TestCheckResourceAttr("incus_cluster_member.member1", "status", "Online"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccClusterMember_automaticRole(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			acctest.PreCheck(t)
+			acctest.PreCheckClustering(t)
+		},
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccClusterMember_roles(`["database"]`),
+				ExpectError: regexp.MustCompile(`value must be none of`),
+			},
+		},
+	})
+}
+
+func TestAccClusterMember_emptyGroups(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			acctest.PreCheck(t)
+			acctest.PreCheckClustering(t)
+		},
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccClusterMember_groups(`[]`),
+				ExpectError: regexp.MustCompile(`set must contain at least 1 elements`),
+			},
+		},
+	})
+}
+
+func testAccClusterMember_roles(roles string) string {
+	return fmt.Sprintf(`
+data "incus_cluster" "test" {}
+
+locals {
+  member_names = [ for k, v in data.incus_cluster.test.members : k ]
+}
+
+resource "incus_cluster_member" "member1" {
+  name              = local.member_names[0]
+  roles             = %s
+  remove_on_destroy = false
+}
+`, roles)
+}
+
+func testAccClusterMember_groups(groups string) string {
+	return fmt.Sprintf(`
+data "incus_cluster" "test" {}
+
+locals {
+  member_names = [ for k, v in data.incus_cluster.test.members : k ]
+}
+
+resource "incus_cluster_member" "member1" {
+  name              = local.member_names[0]
+  groups            = %s
+  remove_on_destroy = false
+}
+`, groups)
+}
+
+func testAccClusterMember_basic(groupName string) string {
+	return fmt.Sprintf(`
+data "incus_cluster" "test" {}
+
+locals {
+  member_names = [ for k, v in data.incus_cluster.test.members : k ]
+}
+
+resource "incus_cluster_group" "group1" {
+  name = "%s"
+
+  # Membership is managed by incus_cluster_member.
+  lifecycle {
+    ignore_changes = [members]
+  }
+}
+
+resource "incus_cluster_member" "member1" {
+  name              = local.member_names[0]
+  description       = "Managed by Terraform"
+  failure_domain    = "rack1"
+  groups            = ["default", incus_cluster_group.group1.name]
+
+  # Keep the member in the test cluster.
+  remove_on_destroy = false
+
+  config = {
+    "scheduler.instance" = "manual"
+  }
+}
+`, groupName)
+}
+
+func testAccClusterMember_defaults(groupName string) string {
+	return fmt.Sprintf(`
+data "incus_cluster" "test" {}
+
+locals {
+  member_names = [ for k, v in data.incus_cluster.test.members : k ]
+}
+
+resource "incus_cluster_group" "group1" {
+  name = "%s"
+
+  # Membership is managed by incus_cluster_member.
+  lifecycle {
+    ignore_changes = [members]
+  }
+}
+
+resource "incus_cluster_member" "member1" {
+  name              = local.member_names[0]
+  failure_domain    = "default"
+  groups            = ["default"]
+  remove_on_destroy = false
+}
+`, groupName)
+}
+
+func testAccClusterMember_evacuated(evacuated bool) string {
+	return fmt.Sprintf(`
+data "incus_cluster" "test" {}
+
+locals {
+  member_names = [ for k, v in data.incus_cluster.test.members : k ]
+}
+
+resource "incus_cluster_member" "member1" {
+  name              = local.member_names[0]
+  evacuated         = %t
+  remove_on_destroy = false
+}
+`, evacuated)
+}
@@ -0,0 +1,54 @@
+package common
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	incus "github.com/lxc/incus/v7/client"
+	"github.com/mitchellh/go-homedir"
+)
+
+// DownloadBackup writes a backup to the given local path using the provided
+// download function and returns the sha256 checksum of the written file. If
+// the download fails, the partially written file is removed.
+func DownloadBackup(outputPath string, download func(req *incus.BackupFileRequest) error) (string, error) {
+	outputPath, err := homedir.Expand(outputPath)
+	if err != nil {
+		return "", fmt.Errorf("Unable to determine output path: %v", err)
+	}
+
+	f, err := os.Create(outputPath)
+	if err != nil {
+		return "", fmt.Errorf("Unable to create output file: %v", err)
+	}
+
+	err = download(&incus.BackupFileRequest{BackupFile: f})
+	if err != nil {
+		_ = f.Close()
+		return "", errors.Join(err, os.Remove(outputPath))
+	}
+
+	_, err = f.Seek(0, io.SeekStart)
+	if err != nil {
+		_ = f.Close()
+		return "", err
+	}
+
+	hash := sha256.New()
+	_, err = io.Copy(hash, f)
+	if err != nil {
+		_ = f.Close()
+		return "", err
+	}
+
+	err = f.Close()
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
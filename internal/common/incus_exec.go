@@ -4,8 +4,10 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"slices"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	incus "github.com/lxc/incus/v7/client"
@@ -20,6 +22,13 @@ type InstanceExecModel struct {
 	GroupID     types.Int64  `tfsdk:"gid"`
 	Timeout     types.String `tfsdk:"timeout"`
 	Trigger     types.String `tfsdk:"trigger"`
+
+	AllowedExitCodes types.List `tfsdk:"allowed_exit_codes"`
+
+	// Computed.
+	Stdout   types.String `tfsdk:"stdout"`
+	Stderr   types.String `tfsdk:"stderr"`
+	ExitCode types.Int64  `tfsdk:"exit_code"`
 }
 
 type InstanceExecConfig struct {
@@ -33,6 +42,35 @@ type InstanceExecConfig struct {
 	Timeout     time.Duration
 	HasTimeout  bool
 	Trigger     string
+
+	AllowedExitCodes []int64
+}
+
+// InstanceExecResult holds the output and the exit code of a command
+// executed in an instance.
+type InstanceExecResult struct {
+	Stdout   string
+	Stderr   string
+	ExitCode int64
+}
+
+// ExecObjectType returns the type of a single exec entry.
+func ExecObjectType() types.ObjectType {
+	return types.ObjectType{
+		AttrTypes: map[string]attr.Type{
+			"command":            types.ListType{ElemType: types.StringType},
+			"environment":        types.MapType{ElemType: types.StringType},
+			"working_dir":        types.StringType,
+			"uid":                types.Int64Type,
+			"gid":                types.Int64Type,
+			"timeout":            types.StringType,
+			"trigger":            types.StringType,
+			"allowed_exit_codes": types.ListType{ElemType: types.Int64Type},
+			"stdout":             types.StringType,
+			"stderr":             types.StringType,
+			"exit_code":          types.Int64Type,
+		},
+	}
 }
 
 func ToExecMap(ctx context.Context, execMap types.Map) (map[string]InstanceExecModel, diag.Diagnostics) {
@@ -49,6 +87,18 @@ func ToExecMap(ctx context.Context, execMap types.Map) (map[string]InstanceExecM
 	return execs, nil
 }
 
+// ToExecMapType converts the given exec entries into a map of objects.
+func ToExecMapType(ctx context.Context, execs map[string]InstanceExecModel) (types.Map, diag.Diagnostics) {
+	return types.MapValueFrom(ctx, ExecObjectType(), execs)
+}
+
+// SetResult stores the given exec result in the computed attributes.
+func (m *InstanceExecModel) SetResult(result InstanceExecResult) {
+	m.Stdout = types.StringValue(result.Stdout)
+	m.Stderr = types.StringValue(result.Stderr)
+	m.ExitCode = types.Int64Value(result.ExitCode)
+}
+
 func ToExecConfig(ctx context.Context, exec InstanceExecModel) (InstanceExecConfig, diag.Diagnostics) {
 	var diags diag.Diagnostics
 	execConfig := InstanceExecConfig{
@@ -105,9 +155,19 @@ func ToExecConfig(ctx context.Context, exec InstanceExecModel) (InstanceExecConf
 		}
 	}
 
+	if !exec.AllowedExitCodes.IsNull() && !exec.AllowedExitCodes.IsUnknown() {
+		diags = exec.AllowedExitCodes.ElementsAs(ctx, &execConfig.AllowedExitCodes, false)
+		if diags.HasError() {
+			return InstanceExecConfig{}, diags
+		}
+	}
+
 	return execConfig, diags
 }
 
+// ExecConfigEqual reports whether two exec configurations run the same
+// command. Allowed exit codes are not compared, because changing them does
+// not require the command to be run again.
 func ExecConfigEqual(a InstanceExecConfig, b InstanceExecConfig) bool {
 	if a.WorkingDir != b.WorkingDir {
 		return false
@@ -152,7 +212,10 @@ func ExecConfigEqual(a InstanceExecConfig, b InstanceExecConfig) bool {
 	return true
 }
 
-func RunInstanceExec(ctx context.Context, server incus.InstanceServer, instanceName string, execConfig InstanceExecConfig) (string, string, error) {
+// RunInstanceExec runs the configured command in the instance and waits for
+// it to finish. An error is returned if the command exits with a non-zero
+// status that is not part of the allowed exit codes.
+func RunInstanceExec(ctx context.Context, server incus.InstanceServer, instanceName string, execConfig InstanceExecConfig) (InstanceExecResult, error) {
 	execReq := api.InstanceExecPost{
 		Command:     execConfig.Command,
 		WaitForWS:   true,
@@ -184,9 +247,17 @@ func RunInstanceExec(ctx context.Context, server incus.InstanceServer, instanceN
 		DataDone: make(chan bool),
 	}
 
+	result := func(exitStatus int64) InstanceExecResult {
+		return InstanceExecResult{
+			Stdout:   stdout.String(),
+			Stderr:   stderr.String(),
+			ExitCode: exitStatus,
+		}
+	}
+
 	op, err := server.ExecInstance(instanceName, execReq, &execArgs)
 	if err != nil {
-		return stdout.String(), stderr.String(), err
+		return result(-1), err
 	}
 
 	waitCtx := ctx
@@ -198,29 +269,29 @@ func RunInstanceExec(ctx context.Context, server incus.InstanceServer, instanceN
 
 	err = op.WaitContext(waitCtx)
 	opAPI := op.Get()
-	exitStatus := 0
+	exitStatus := int64(0)
 	if opAPI.Metadata != nil {
 		exitStatusRaw, ok := opAPI.Metadata["return"].(float64)
 		if ok {
-			exitStatus = int(exitStatusRaw)
+			exitStatus = int64(exitStatusRaw)
 		}
 	}
 
 	if err != nil {
-		return stdout.String(), stderr.String(), err
+		return result(-1), err
 	}
 
 	if execArgs.DataDone != nil {
 		select {
 		case <-execArgs.DataDone:
 		case <-waitCtx.Done():
-			return stdout.String(), stderr.String(), waitCtx.Err()
+			return result(-1), waitCtx.Err()
 		}
 	}
 
-	if exitStatus != 0 {
-		return stdout.String(), stderr.String(), fmt.Errorf("exec returned non-zero status %d", exitStatus)
+	if exitStatus != 0 && !slices.Contains(execConfig.AllowedExitCodes, exitStatus) {
+		return result(exitStatus), fmt.Errorf("exec returned non-zero status %d", exitStatus)
 	}
 
-	return stdout.String(), stderr.String(), nil
+	return result(exitStatus), nil
 }
@@ -2,8 +2,12 @@ package common
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"io"
+	"io/fs"
 	"os"
 	"path"
 	"path/filepath"
@@ -14,6 +18,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	incus "github.com/lxc/incus/v7/client"
 	"github.com/mitchellh/go-homedir"
+	"github.com/pkg/sftp"
 
 	tfierrors "github.com/lxc/terraform-provider-incus/internal/errors"
 )
@@ -28,12 +33,322 @@ type InstanceFileModel struct {
 	DirectoryMode types.String `tfsdk:"directory_mode"`
 	CreateDirs    types.Bool   `tfsdk:"create_directories"`
 	Append        types.Bool   `tfsdk:"append"`
+	SourceDir     types.String `tfsdk:"source_dir"`
+	DeleteRemoved types.Bool   `tfsdk:"delete_removed"`
+
+	// Computed.
+	SourceDirHash types.String `tfsdk:"source_dir_hash"`
+}
+
+// IsDirectory returns true if the file entry pushes a local directory tree.
+func (m InstanceFileModel) IsDirectory() bool {
+	return !m.SourceDir.IsNull()
 }
 
 type fileInfo struct {
 	Type string
 }
 
+// fileOps holds the file operations of an instance or a storage volume.
+type fileOps struct {
+	info   func(path string) (*incus.InstanceFileResponse, error)
+	read   func(path string) (io.ReadCloser, error)
+	create func(path string, args incus.InstanceFileArgs) error
+	delete func(path string) error
+}
+
+// FileTarget transfers files to an instance or a storage volume.
+//
+// On first use, an SFTP connection is opened to the target and reused for
+// all further transfers until Close is called. If the server does not
+// support SFTP, the file API is used instead.
+type FileTarget struct {
+	api      fileOps
+	openSFTP func() (*sftp.Client, error)
+
+	ops    *fileOps
+	client *sftp.Client
+}
+
+// NewInstanceFileTarget returns a FileTarget for the given instance.
+func NewInstanceFileTarget(server incus.InstanceServer, instanceName string) *FileTarget {
+	return &FileTarget{
+		api: fileOps{
+			info: func(path string) (*incus.InstanceFileResponse, error) {
+				return apiFileInfo(server.GetInstanceFile(instanceName, path))
+			},
+			read: func(path string) (io.ReadCloser, error) {
+				content, _, err := server.GetInstanceFile(instanceName, path)
+				return content, err
+			},
+			create: func(path string, args incus.InstanceFileArgs) error {
+				return server.CreateInstanceFile(instanceName, path, args)
+			},
+			delete: func(path string) error {
+				return server.DeleteInstanceFile(instanceName, path)
+			},
+		},
+		openSFTP: func() (*sftp.Client, error) {
+			return server.GetInstanceFileSFTP(instanceName)
+		},
+	}
+}
+
+// NewVolumeFileTarget returns a FileTarget for the given storage volume.
+func NewVolumeFileTarget(server incus.InstanceServer, pool, volumeType, volumeName string) *FileTarget {
+	return &FileTarget{
+		api: fileOps{
+			info: func(path string) (*incus.InstanceFileResponse, error) {
+				return apiFileInfo(server.GetStorageVolumeFile(pool, volumeType, volumeName, path))
+			},
+			read: func(path string) (io.ReadCloser, error) {
+				content, _, err := server.GetStorageVolumeFile(pool, volumeType, volumeName, path)
+				return content, err
+			},
+			create: func(path string, args incus.InstanceFileArgs) error {
+				return server.CreateStorageVolumeFile(pool, volumeType, volumeName, path, args)
+			},
+			delete: func(path string) error {
+				return server.DeleteStorageVolumeFile(pool, volumeType, volumeName, path)
+			},
+		},
+		openSFTP: func() (*sftp.Client, error) {
+			return server.GetStorageVolumeFileSFTP(pool, volumeType, volumeName)
+		},
+	}
+}
+
+// Close closes the SFTP connection, if one was opened.
+func (t *FileTarget) Close() error {
+	if t.client == nil {
+		return nil
+	}
+
+	err := t.client.Close()
+	t.client = nil
+	t.ops = nil
+
+	return err
+}
+
+// connect returns the file operations of the target, opening the SFTP
+// connection on first use.
+func (t *FileTarget) connect() fileOps {
+	if t.ops != nil {
+		return *t.ops
+	}
+
+	ops := t.api
+
+	client, err := t.openSFTP()
+	if err == nil && client != nil {
+		t.client = client
+		ops = sftpFileOps(client)
+	}
+
+	t.ops = &ops
+	return ops
+}
+
+// apiFileInfo discards the content returned by the file API.
+func apiFileInfo(content io.ReadCloser, resp *incus.InstanceFileResponse, err error) (*incus.InstanceFileResponse, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	if content != nil {
+		_ = content.Close()
+	}
+
+	return resp, nil
+}
+
+// sftpFileOps returns file operations backed by the given SFTP client.
+func sftpFileOps(client *sftp.Client) fileOps {
+	return fileOps{
+		info: func(path string) (*incus.InstanceFileResponse, error) {
+			info, err := client.Lstat(path)
+			if err != nil {
+				return nil, err
+			}
+
+			resp := &incus.InstanceFileResponse{
+				Mode: int(info.Mode().Perm()),
+			}
+
+			stat, ok := info.Sys().(*sftp.FileStat)
+			if ok {
+				resp.UID = int64(stat.UID)
+				resp.GID = int64(stat.GID)
+			}
+
+			switch {
+			case info.IsDir():
+				resp.Type = "directory"
+
+				entries, err := client.ReadDir(path)
+				if err != nil {
+					return nil, err
+				}
+
+				for _, entry := range entries {
+					resp.Entries = append(resp.Entries, entry.Name())
+				}
+			case info.Mode()&os.ModeSymlink != 0:
+				resp.Type = "symlink"
+			default:
+				resp.Type = "file"
+			}
+
+			return resp, nil
+		},
+		read: func(path string) (io.ReadCloser, error) {
+			return client.Open(path)
+		},
+		create: func(path string, args incus.InstanceFileArgs) error {
+			switch args.Type {
+			case "directory":
+				err := client.Mkdir(path)
+				if err != nil {
+					// Existing directories are updated in place.
+					info, statErr := client.Lstat(path)
+					if statErr != nil || !info.IsDir() {
+						return err
+					}
+				}
+			case "file":
+				flags := os.O_WRONLY | os.O_CREATE
+				if args.WriteMode == "append" {
+					flags |= os.O_APPEND
+				} else {
+					flags |= os.O_TRUNC
+				}
+
+				f, err := client.OpenFile(path, flags)
+				if err != nil {
+					return err
+				}
+
+				// The SFTP server writes at the offsets sent by the client,
+				// so appending requires starting at the end of the file.
+				if args.WriteMode == "append" {
+					_, err = f.Seek(0, io.SeekEnd)
+					if err != nil {
+						_ = f.Close()
+						return err
+					}
+				}
+
+				if args.Content != nil {
+					_, err = f.ReadFrom(args.Content)
+					if err != nil {
+						_ = f.Close()
+						return err
+					}
+				}
+
+				err = f.Close()
+				if err != nil {
+					return err
+				}
+			default:
+				return fmt.Errorf("Unsupported file type %q", args.Type)
+			}
+
+			// Unlike the file API, mode and ownership are also applied
+			// to existing files.
+			err := client.Chmod(path, os.FileMode(args.Mode))
+			if err != nil {
+				return err
+			}
+
+			return client.Chown(path, int(args.UID), int(args.GID))
+		},
+		delete: client.Remove,
+	}
+}
+
+// info returns the type and the entries of the given path.
+func (t *FileTarget) info(path string) (*incus.InstanceFileResponse, error) {
+	return t.connect().info(path)
+}
+
+// create creates a file or a directory.
+func (t *FileTarget) create(path string, args incus.InstanceFileArgs) error {
+	return t.connect().create(path, args)
+}
+
+// isSFTP returns true if the target is accessed over SFTP.
+func (t *FileTarget) isSFTP() bool {
+	t.connect()
+	return t.client != nil
+}
+
+// hasContent returns true if the content of the given path on the target
+// matches the content of the local file.
+func (t *FileTarget) hasContent(targetPath string, localPath string) (bool, error) {
+	local, err := os.Open(localPath)
+	if err != nil {
+		return false, err
+	}
+
+	localHash, err := readSHA256(local)
+	if err != nil {
+		return false, err
+	}
+
+	content, err := t.connect().read(targetPath)
+	if err != nil {
+		return false, err
+	}
+
+	targetHash, err := readSHA256(content)
+	if err != nil {
+		return false, err
+	}
+
+	return localHash == targetHash, nil
+}
+
+// readSHA256 returns the hex encoded SHA-256 hash of the content read from
+// r and closes it.
+func readSHA256(r io.ReadCloser) (string, error) {
+	hash := sha256.New()
+	_, err := io.Copy(hash, r)
+	_ = r.Close()
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// fileInfo returns the type of the given path.
+func (t *FileTarget) fileInfo(path string) (fileInfo, error) {
+	resp, err := t.info(path)
+	if err != nil {
+		return fileInfo{}, err
+	}
+
+	return fileInfo{Type: resp.Type}, nil
+}
+
+// Delete deletes a file or an empty directory. Missing files are ignored.
+func (t *FileTarget) Delete(targetPath string) error {
+	err := t.connect().delete(targetPath)
+	if err != nil && !isFileNotFound(err) {
+		return err
+	}
+
+	return nil
+}
+
+// isFileNotFound returns true if the error of the file API or of the SFTP
+// client indicates a missing file.
+func isFileNotFound(err error) bool {
+	return tfierrors.IsNotFoundError(err) || errors.Is(err, fs.ErrNotExist)
+}
+
 // ToFileMap converts files from types.Set into map[string]IncusFileModel.
 func ToFileMap(ctx context.Context, fileSet types.Set) (map[string]InstanceFileModel, diag.Diagnostics) {
 	if fileSet.IsNull() || fileSet.IsUnknown() {
@@ -55,18 +370,8 @@ func ToFileMap(ctx context.Context, fileSet types.Set) (map[string]InstanceFileM
 	return fileMap, diags
 }
 
-// InstanceFileDelete deletes a file from an instance.
-func InstanceFileDelete(server incus.InstanceServer, instanceName string, targetPath string) error {
-	err := server.DeleteInstanceFile(instanceName, targetPath)
-	if err != nil && !tfierrors.IsNotFoundError(err) {
-		return err
-	}
-
-	return nil
-}
-
-// InstanceFileUpload uploads a file to an instance.
-func InstanceFileUpload(server incus.InstanceServer, instanceName string, file InstanceFileModel) error {
+// Upload uploads a file or a directory tree.
+func (t *FileTarget) Upload(file InstanceFileModel) error {
 	content := file.Content.ValueString()
 	sourcePath := file.SourcePath.ValueString()
 
@@ -74,6 +379,14 @@ func InstanceFileUpload(server incus.InstanceServer, instanceName string, file I
 		return fmt.Errorf("File %q and %q are mutually exclusive.", "content", "source_path")
 	}
 
+	if file.IsDirectory() {
+		if content != "" || sourcePath != "" {
+			return fmt.Errorf("File %q is mutually exclusive with %q and %q.", "source_dir", "content", "source_path")
+		}
+
+		return pushDirectory(t, file)
+	}
+
 	targetPath := file.TargetPath.ValueString()
 
 	fileMode := file.Mode.ValueString()
@@ -105,7 +418,7 @@ func InstanceFileUpload(server incus.InstanceServer, instanceName string, file I
 		args.Content = strings.NewReader(content)
 	}
 
-	// If a source was specified, read the contents of the source file.
+	// If a source was specified, stream the contents of the source file.
 	if sourcePath != "" {
 		path, err := homedir.Expand(sourcePath)
 		if err != nil {
@@ -141,13 +454,13 @@ func InstanceFileUpload(server incus.InstanceServer, instanceName string, file I
 			GID:  args.GID,
 		}
 
-		err = instanceRecursiveMkdir(server, instanceName, path.Dir(targetPath), dirArgs)
+		err = recursiveMkdir(path.Dir(targetPath), dirArgs, t.fileInfo, t.create)
 		if err != nil {
 			return fmt.Errorf("Could not create directories for file %q: %v", targetPath, err)
 		}
 	}
 
-	err = server.CreateInstanceFile(instanceName, targetPath, *args)
+	err = t.create(targetPath, *args)
 	if err != nil {
 		return fmt.Errorf("Could not upload file %q: %v", targetPath, err)
 	}
@@ -155,6 +468,22 @@ func InstanceFileUpload(server incus.InstanceServer, instanceName string, file I
 	return nil
 }
 
+// InstanceFileDelete deletes a file from an instance.
+func InstanceFileDelete(server incus.InstanceServer, instanceName string, targetPath string) error {
+	target := NewInstanceFileTarget(server, instanceName)
+	defer target.Close()
+
+	return target.Delete(targetPath)
+}
+
+// InstanceFileUpload uploads a file or a directory tree to an instance.
+func InstanceFileUpload(server incus.InstanceServer, instanceName string, file InstanceFileModel) error {
+	target := NewInstanceFileTarget(server, instanceName)
+	defer target.Close()
+
+	return target.Upload(file)
+}
+
 // recursiveMkdir recursively creates all missing directories in the given path.
 //
 // The function first walks backwards through the path components to find the
@@ -180,8 +509,11 @@ func recursiveMkdir(
 	parts := strings.Split(cleanPath, "/")
 	i := len(parts)
 
+	// Joining the parts drops the leading "/", so the paths passed to the
+	// callbacks are made absolute again.
+
 	for ; i >= 1; i-- {
-		currentPath := filepath.Join(parts[:i]...)
+		currentPath := "/" + filepath.Join(parts[:i]...)
 
 		info, err := getFileInfo(currentPath)
 		if err != nil {
@@ -197,8 +529,8 @@ func recursiveMkdir(
 	}
 
 	for ; i <= len(parts); i++ {
-		cur := filepath.Join(parts[:i]...)
-		if cur == "" {
+		cur := "/" + filepath.Join(parts[:i]...)
+		if cur == "/" {
 			continue
 		}
 
@@ -210,93 +542,67 @@ func recursiveMkdir(
 	return nil
 }
 
-// instanceRecursiveMkdir recursively creates directories on the target instance.
-func instanceRecursiveMkdir(server incus.InstanceServer, instanceName string, path string, dirArgs incus.InstanceFileArgs) error {
-	return recursiveMkdir(
-		path,
-		dirArgs,
-		func(path string) (fileInfo, error) {
-			_, resp, err := server.GetInstanceFile(instanceName, path)
-			if err != nil {
-				return fileInfo{}, err
-			}
-
-			return fileInfo{Type: resp.Type}, nil
-		},
-		func(path string, args incus.InstanceFileArgs) error {
-			return server.CreateInstanceFile(instanceName, path, args)
-		},
-	)
-}
-
 // VolumeFileDelete deletes a file from a storage volume.
 func VolumeFileDelete(server incus.InstanceServer, pool, volumeType, volumeName, targetPath string) error {
-	err := server.DeleteStorageVolumeFile(pool, volumeType, volumeName, targetPath)
-	if err != nil && !tfierrors.IsNotFoundError(err) {
-		return err
-	}
+	target := NewVolumeFileTarget(server, pool, volumeType, volumeName)
+	defer target.Close()
 
-	return nil
+	return target.Delete(targetPath)
 }
 
-// VolumeFileUpload uploads a file to a storage volume.
+// VolumeFileUpload uploads a file or a directory tree to a storage volume.
 func VolumeFileUpload(server incus.InstanceServer, pool, volumeType, volumeName string, file InstanceFileModel) error {
-	content := file.Content.ValueString()
-	sourcePath := file.SourcePath.ValueString()
+	target := NewVolumeFileTarget(server, pool, volumeType, volumeName)
+	defer target.Close()
 
-	if content != "" && sourcePath != "" {
-		return fmt.Errorf("File %q and %q are mutually exclusive.", "content", "source_path")
-	}
-
-	targetPath := file.TargetPath.ValueString()
-
-	fileMode := file.Mode.ValueString()
-	if fileMode == "" {
-		fileMode = "0755"
-	}
-
-	mode, err := strconv.ParseUint(fileMode, 8, 32)
-	if err != nil {
-		return fmt.Errorf("Failed to parse file mode: %v", err)
-	}
+	return target.Upload(file)
+}
 
-	// Build the file creation request, without the content.
-	args := &incus.InstanceFileArgs{
-		Type: "file",
-		Mode: int(mode),
-		UID:  file.UserID.ValueInt64(),
-		GID:  file.GroupID.ValueInt64(),
+// HasFileContentChanged determines if the content or source path of the new file differs from the corresponding old file.
+func HasFileContentChanged(newFile InstanceFileModel, oldFile InstanceFileModel) bool {
+	if newFile.IsDirectory() || oldFile.IsDirectory() {
+		return !newFile.SourceDir.Equal(oldFile.SourceDir) ||
+			!newFile.SourceDirHash.Equal(oldFile.SourceDirHash) ||
+			newFile.DeleteRemoved.ValueBool() != oldFile.DeleteRemoved.ValueBool()
 	}
 
-	if file.Append.ValueBool() {
-		args.WriteMode = "append"
-	} else {
-		args.WriteMode = "overwrite"
-	}
+	hasNewContent := !newFile.Content.IsNull()
+	hasOldContent := !oldFile.Content.IsNull()
+	hasNewSourcePath := !newFile.SourcePath.IsNull()
+	hasOldSourcePath := !oldFile.SourcePath.IsNull()
 
-	// If content was specified, read the string.
-	if content != "" {
-		args.Content = strings.NewReader(content)
+	switch {
+	case hasNewContent && hasOldContent:
+		return newFile.Content.ValueString() != oldFile.Content.ValueString()
+	case hasNewSourcePath && hasOldSourcePath:
+		return newFile.SourcePath.ValueString() != oldFile.SourcePath.ValueString()
+	case (hasNewSourcePath && hasOldContent) || (hasNewContent && hasOldSourcePath):
+		return true
 	}
 
-	// If a source was specified, read the contents of the source file.
-	if sourcePath != "" {
-		currentPath, err := homedir.Expand(sourcePath)
-		if err != nil {
-			return fmt.Errorf("Unable to determine source file currentPath: %v", err)
-		}
+	return false
+}
 
-		f, err := os.Open(currentPath)
-		if err != nil {
-			return fmt.Errorf("Unable to read source file: %v", err)
-		}
-		defer func(f *os.File) {
-			err = errors.Join(err, f.Close())
-		}(f)
+// HasFilePermissionChanged determines if the mode, user ID, or group ID of the new file differs from the corresponding old file.
+func HasFilePermissionChanged(newFile InstanceFileModel, oldFile InstanceFileModel) bool {
+	return newFile.Mode.ValueString() != oldFile.Mode.ValueString() ||
+		newFile.UserID.ValueInt64() != oldFile.UserID.ValueInt64() ||
+		newFile.GroupID.ValueInt64() != oldFile.GroupID.ValueInt64()
+}
 
-		args.Content = f
+// pushDirectory uploads the local directory tree of the given file entry to
+// the target path, keeping relative paths and modes. If requested, files and
+// directories below the target path that do not exist locally are removed.
+func pushDirectory(target *FileTarget, file InstanceFileModel) error {
+	sourceDir, err := homedir.Expand(file.SourceDir.ValueString())
+	if err != nil {
+		return fmt.Errorf("Unable to determine source directory path: %v", err)
 	}
 
+	targetPath := path.Clean(file.TargetPath.ValueString())
+	uid := file.UserID.ValueInt64()
+	gid := file.GroupID.ValueInt64()
+
 	if file.CreateDirs.ValueBool() {
 		directoryMode := "0755"
 		if file.DirectoryMode.ValueString() != "" {
@@ -311,65 +617,276 @@ func VolumeFileUpload(server incus.InstanceServer, pool, volumeType, volumeName
 		dirArgs := incus.InstanceFileArgs{
 			Type: "directory",
 			Mode: int(dirMode),
-			UID:  args.UID,
-			GID:  args.GID,
+			UID:  uid,
+			GID:  gid,
 		}
 
-		err = volumeRecursiveMkdir(server, pool, volumeType, volumeName, path.Dir(targetPath), dirArgs)
+		err = recursiveMkdir(path.Dir(targetPath), dirArgs, target.fileInfo, target.create)
 		if err != nil {
-			return fmt.Errorf("Could not create directories for file %q: %v", targetPath, err)
+			return fmt.Errorf("Could not create directories for %q: %v", targetPath, err)
 		}
 	}
 
-	err = server.CreateStorageVolumeFile(pool, volumeType, volumeName, targetPath, *args)
+	// Paths on the target that exist locally.
+	pushed := make(map[string]bool)
+
+	err = filepath.WalkDir(sourceDir, func(localPath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		relPath, err := filepath.Rel(sourceDir, localPath)
+		if err != nil {
+			return err
+		}
+
+		destPath := path.Join(targetPath, filepath.ToSlash(relPath))
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		args := incus.InstanceFileArgs{
+			Mode: int(info.Mode().Perm()),
+			UID:  uid,
+			GID:  gid,
+		}
+
+		switch {
+		case d.IsDir():
+			pushed[destPath] = true
+
+			// Existing directories are only updated if their mode or
+			// ownership differs.
+			resp, err := target.info(destPath)
+			if err == nil && resp.Type == "directory" && resp.Mode == args.Mode && resp.UID == args.UID && resp.GID == args.GID {
+				return nil
+			}
+
+			args.Type = "directory"
+			err = target.create(destPath, args)
+			if err != nil {
+				return fmt.Errorf("Could not upload %q: %v", destPath, err)
+			}
+		case d.Type().IsRegular():
+			pushed[destPath] = true
+
+			// Existing files are only uploaded again if their content, mode
+			// or ownership differs.
+			resp, err := target.info(destPath)
+			if err == nil && resp.Type == "file" && resp.Mode == args.Mode && resp.UID == args.UID && resp.GID == args.GID {
+				unchanged, err := target.hasContent(destPath, localPath)
+				if err == nil && unchanged {
+					return nil
+				}
+			}
+
+			// The file API does not apply mode and ownership to existing
+			// files, so the old file is deleted first. Over SFTP, they are
+			// applied on upload.
+			if !target.isSFTP() {
+				err = target.Delete(destPath)
+				if err != nil {
+					return err
+				}
+			}
+
+			err = pushFile(target, localPath, destPath, args)
+			if err != nil {
+				return fmt.Errorf("Could not upload %q: %v", destPath, err)
+			}
+		}
+
+		// Symlinks and special files are skipped.
+		return nil
+	})
 	if err != nil {
-		return fmt.Errorf("Could not upload file %q: %v", targetPath, err)
+		return fmt.Errorf("Could not upload directory %q: %v", sourceDir, err)
+	}
+
+	if file.DeleteRemoved.ValueBool() {
+		err = deleteRemoved(target, targetPath, pushed)
+		if err != nil {
+			return fmt.Errorf("Could not delete removed files from %q: %v", targetPath, err)
+		}
 	}
 
 	return nil
 }
 
-// volumeRecursiveMkdir recursively creates directories in storage pool volume.
-func volumeRecursiveMkdir(server incus.InstanceServer, pool, volumeType, volumeName, path string, dirArgs incus.InstanceFileArgs) error {
-	return recursiveMkdir(
-		path,
-		dirArgs,
-		func(path string) (fileInfo, error) {
-			_, resp, err := server.GetStorageVolumeFile(pool, volumeType, volumeName, path)
+// pushFile uploads the local file to the target path. The local file is
+// closed once the upload is done.
+func pushFile(target *FileTarget, localPath string, destPath string, args incus.InstanceFileArgs) error {
+	f, err := os.Open(localPath)
+	if err != nil {
+		return err
+	}
+
+	args.Type = "file"
+	args.WriteMode = "overwrite"
+	args.Content = f
+
+	err = target.create(destPath, args)
+	if err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+// deleteRemoved removes all entries below dir that are not part of keep.
+func deleteRemoved(target *FileTarget, dir string, keep map[string]bool) error {
+	resp, err := target.info(dir)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range resp.Entries {
+		entryPath := path.Join(dir, entry)
+		if !keep[entryPath] {
+			err := deleteRecursive(target, entryPath)
 			if err != nil {
-				return fileInfo{}, err
+				return err
 			}
 
-			return fileInfo{Type: resp.Type}, nil
-		},
-		func(path string, args incus.InstanceFileArgs) error {
-			return server.CreateStorageVolumeFile(pool, volumeType, volumeName, path, args)
-		},
-	)
+			continue
+		}
+
+		entryInfo, err := target.info(entryPath)
+		if err != nil {
+			return err
+		}
+
+		if entryInfo.Type == "directory" {
+			err := deleteRemoved(target, entryPath, keep)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
 }
 
-// HasFileContentChanged determines if the content or source path of the new file differs from the corresponding old file.
-func HasFileContentChanged(newFile InstanceFileModel, oldFile InstanceFileModel) bool {
-	hasNewContent := !newFile.Content.IsNull()
-	hasOldContent := !oldFile.Content.IsNull()
-	hasNewSourcePath := !newFile.SourcePath.IsNull()
-	hasOldSourcePath := !oldFile.SourcePath.IsNull()
+// deleteRecursive removes the given path including all its entries.
+func deleteRecursive(target *FileTarget, entryPath string) error {
+	resp, err := target.info(entryPath)
+	if err != nil {
+		if isFileNotFound(err) {
+			return nil
+		}
 
-	switch {
-	case hasNewContent && hasOldContent:
-		return newFile.Content.ValueString() != oldFile.Content.ValueString()
-	case hasNewSourcePath && hasOldSourcePath:
-		return newFile.SourcePath.ValueString() != oldFile.SourcePath.ValueString()
-	case (hasNewSourcePath && hasOldContent) || (hasNewContent && hasOldSourcePath):
-		return true
+		return err
 	}
 
-	return false
+	if resp.Type == "directory" {
+		for _, entry := range resp.Entries {
+			err := deleteRecursive(target, path.Join(entryPath, entry))
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return target.Delete(entryPath)
 }
 
-// HasFilePermissionChanged determines if the mode, user ID, or group ID of the new file differs from the corresponding old file.
-func HasFilePermissionChanged(newFile InstanceFileModel, oldFile InstanceFileModel) bool {
-	return newFile.Mode.ValueString() != oldFile.Mode.ValueString() ||
-		newFile.UserID.ValueInt64() != oldFile.UserID.ValueInt64() ||
-		newFile.GroupID.ValueInt64() != oldFile.GroupID.ValueInt64()
+// SourceDirHash returns a hash over the relative paths, modes and contents
+// of all directories and regular files in the given local directory tree.
+func SourceDirHash(sourceDir string) (string, error) {
+	sourceDir, err := homedir.Expand(sourceDir)
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.New()
+	err = filepath.WalkDir(sourceDir, func(localPath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !d.IsDir() && !d.Type().IsRegular() {
+			return nil
+		}
+
+		relPath, err := filepath.Rel(sourceDir, localPath)
+		if err != nil {
+			return err
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		_, _ = fmt.Fprintf(hash, "%s\x00%o\x00", filepath.ToSlash(relPath), info.Mode())
+
+		if d.Type().IsRegular() {
+			f, err := os.Open(localPath)
+			if err != nil {
+				return err
+			}
+
+			contentHash := sha256.New()
+			_, err = io.Copy(contentHash, f)
+			_ = f.Close()
+			if err != nil {
+				return err
+			}
+
+			_, _ = hash.Write(contentHash.Sum(nil))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// PlanSourceDirHashes sets the tree hash of all file entries that push a
+// local directory, so that changes to the local files are detected.
+func PlanSourceDirHashes(ctx context.Context, fileSet types.Set) (types.Set, diag.Diagnostics) {
+	if fileSet.IsNull() || fileSet.IsUnknown() {
+		return fileSet, nil
+	}
+
+	files := make([]InstanceFileModel, 0, len(fileSet.Elements()))
+	diags := fileSet.ElementsAs(ctx, &files, false)
+	if diags.HasError() {
+		return fileSet, diags
+	}
+
+	for i, f := range files {
+		switch {
+		case f.SourceDir.IsUnknown():
+			f.SourceDirHash = types.StringUnknown()
+		case f.SourceDir.IsNull():
+			f.SourceDirHash = types.StringNull()
+		default:
+			hash, err := SourceDirHash(f.SourceDir.ValueString())
+			if errors.Is(err, fs.ErrNotExist) {
+				// The directory may be created later on, e.g. by
+				// another resource, so the hash is not known yet.
+				f.SourceDirHash = types.StringUnknown()
+				files[i] = f
+				continue
+			}
+
+			if err != nil {
+				diags.AddError(fmt.Sprintf("Failed to read source directory %q", f.SourceDir.ValueString()), err.Error())
+				return fileSet, diags
+			}
+
+			f.SourceDirHash = types.StringValue(hash)
+		}
+
+		files[i] = f
+	}
+
+	return types.SetValueFrom(ctx, fileSet.ElementType(ctx), files)
 }
@@ -0,0 +1,545 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	incus "github.com/lxc/incus/v7/client"
+	"github.com/lxc/incus/v7/shared/api"
+	"github.com/pkg/sftp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSourceDirHash(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "sub"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.txt"), []byte("a"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "sub", "b.txt"), []byte("b"), 0o644))
+
+	hash, err := SourceDirHash(dir)
+	require.NoError(t, err)
+
+	again, err := SourceDirHash(dir)
+	require.NoError(t, err)
+	assert.Equal(t, hash, again)
+
+	// Content changes are detected.
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "sub", "b.txt"), []byte("c"), 0o644))
+	contentHash, err := SourceDirHash(dir)
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, contentHash)
+
+	// Mode changes are detected.
+	require.NoError(t, os.Chmod(filepath.Join(dir, "a.txt"), 0o600))
+	modeHash, err := SourceDirHash(dir)
+	require.NoError(t, err)
+	assert.NotEqual(t, contentHash, modeHash)
+
+	// Removed files are detected.
+	require.NoError(t, os.Remove(filepath.Join(dir, "a.txt")))
+	removedHash, err := SourceDirHash(dir)
+	require.NoError(t, err)
+	assert.NotEqual(t, modeHash, removedHash)
+}
+
+func TestSourceDirHash_missingDirectory(t *testing.T) {
+	_, err := SourceDirHash(filepath.Join(t.TempDir(), "missing"))
+	assert.Error(t, err)
+}
+
+// recordingFileCmder records the mode and ownership changes sent over SFTP
+// instead of applying them, as the in-memory handler does not support them.
+type recordingFileCmder struct {
+	sftp.FileCmder
+
+	mu      sync.Mutex
+	modes   map[string]os.FileMode
+	owners  map[string][2]uint32
+	removed []string
+}
+
+func (c *recordingFileCmder) Filecmd(r *sftp.Request) error {
+	if r.Method == "Remove" {
+		c.mu.Lock()
+		c.removed = append(c.removed, r.Filepath)
+		c.mu.Unlock()
+	}
+
+	flags := r.AttrFlags()
+	if r.Method != "Setstat" || flags.Size {
+		return c.FileCmder.Filecmd(r)
+	}
+
+	attrs := r.Attributes()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if flags.Permissions {
+		c.modes[r.Filepath] = attrs.FileMode().Perm()
+	}
+
+	if flags.UidGid {
+		c.owners[r.Filepath] = [2]uint32{attrs.UID, attrs.GID}
+	}
+
+	return nil
+}
+
+// newTestSFTPClient returns an SFTP client connected to an in-memory server.
+func newTestSFTPClient(t *testing.T) (*sftp.Client, *recordingFileCmder) {
+	t.Helper()
+
+	handlers := sftp.InMemHandler()
+	cmder := &recordingFileCmder{
+		FileCmder: handlers.FileCmd,
+		modes:     make(map[string]os.FileMode),
+		owners:    make(map[string][2]uint32),
+	}
+
+	handlers.FileCmd = cmder
+
+	serverConn, clientConn := net.Pipe()
+	server := sftp.NewRequestServer(serverConn, handlers)
+	go func() {
+		_ = server.Serve()
+	}()
+
+	client, err := sftp.NewClientPipe(clientConn, clientConn)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = client.Close()
+		_ = server.Close()
+	})
+
+	return client, cmder
+}
+
+// newTestSFTPFileTarget returns a FileTarget that uses the given SFTP client
+// and fails the test if the file API is used.
+func newTestSFTPFileTarget(t *testing.T, client *sftp.Client) *FileTarget {
+	t.Helper()
+
+	unexpected := func(path string) error {
+		t.Fatalf("Unexpected file API call for %q", path)
+		return nil
+	}
+
+	return &FileTarget{
+		api: fileOps{
+			info: func(path string) (*incus.InstanceFileResponse, error) {
+				return nil, unexpected(path)
+			},
+			read: func(path string) (io.ReadCloser, error) {
+				return nil, unexpected(path)
+			},
+			create: func(path string, args incus.InstanceFileArgs) error {
+				return unexpected(path)
+			},
+			delete: unexpected,
+		},
+		openSFTP: func() (*sftp.Client, error) {
+			return client, nil
+		},
+	}
+}
+
+func writeTestSFTPFile(t *testing.T, client *sftp.Client, path string, content string) {
+	t.Helper()
+
+	f, err := client.Create(path)
+	require.NoError(t, err)
+
+	_, err = f.Write([]byte(content))
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+}
+
+func readTestSFTPFile(t *testing.T, client *sftp.Client, path string) string {
+	t.Helper()
+
+	f, err := client.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	content, err := io.ReadAll(f)
+	require.NoError(t, err)
+
+	return string(content)
+}
+
+func TestFileTarget_fallbackToFileAPI(t *testing.T) {
+	files := map[string]string{"/": "directory"}
+	var created []string
+	var openCalls int
+
+	target := &FileTarget{
+		api: fileOps{
+			info: func(path string) (*incus.InstanceFileResponse, error) {
+				fileType, ok := files[path]
+				if !ok {
+					return nil, api.StatusErrorf(http.StatusNotFound, "not found")
+				}
+
+				return &incus.InstanceFileResponse{Type: fileType}, nil
+			},
+			create: func(path string, args incus.InstanceFileArgs) error {
+				files[path] = args.Type
+				created = append(created, path)
+				return nil
+			},
+			delete: func(path string) error {
+				_, ok := files[path]
+				if !ok {
+					return api.StatusErrorf(http.StatusNotFound, "not found")
+				}
+
+				delete(files, path)
+				return nil
+			},
+		},
+		openSFTP: func() (*sftp.Client, error) {
+			openCalls++
+			return nil, errors.New("SFTP is not supported")
+		},
+	}
+
+	err := target.Upload(InstanceFileModel{
+		Content:    types.StringValue("hello"),
+		TargetPath: types.StringValue("/opt/app/config"),
+		CreateDirs: types.BoolValue(true),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/opt", "/opt/app", "/opt/app/config"}, created)
+
+	// Missing files are ignored on deletion.
+	require.NoError(t, target.Delete("/opt/app/missing"))
+	require.NoError(t, target.Delete("/opt/app/config"))
+	assert.NotContains(t, files, "/opt/app/config")
+
+	// The SFTP connection is only attempted once.
+	assert.Equal(t, 1, openCalls)
+	require.NoError(t, target.Close())
+}
+
+func TestFileTarget_sftpCreateDirectories(t *testing.T) {
+	client, cmder := newTestSFTPClient(t)
+	target := newTestSFTPFileTarget(t, client)
+
+	err := target.Upload(InstanceFileModel{
+		Content:       types.StringValue("hello"),
+		TargetPath:    types.StringValue("/opt/app/config"),
+		Mode:          types.StringValue("0644"),
+		DirectoryMode: types.StringValue("0700"),
+		CreateDirs:    types.BoolValue(true),
+	})
+	require.NoError(t, err)
+
+	for _, dir := range []string{"/opt", "/opt/app"} {
+		info, err := client.Stat(dir)
+		require.NoError(t, err)
+		assert.True(t, info.IsDir(), dir)
+		assert.Equal(t, os.FileMode(0o700), cmder.modes[dir], dir)
+	}
+
+	assert.Equal(t, "hello", readTestSFTPFile(t, client, "/opt/app/config"))
+	assert.Equal(t, os.FileMode(0o644), cmder.modes["/opt/app/config"])
+}
+
+func TestFileTarget_sftpExistingFile(t *testing.T) {
+	client, cmder := newTestSFTPClient(t)
+	target := newTestSFTPFileTarget(t, client)
+
+	writeTestSFTPFile(t, client, "/config", "old content")
+
+	err := target.Upload(InstanceFileModel{
+		Content:    types.StringValue("new"),
+		TargetPath: types.StringValue("/config"),
+		Mode:       types.StringValue("0600"),
+		UserID:     types.Int64Value(1000),
+		GroupID:    types.Int64Value(1001),
+	})
+	require.NoError(t, err)
+
+	// Content is replaced and mode and ownership are applied.
+	assert.Equal(t, "new", readTestSFTPFile(t, client, "/config"))
+	assert.Equal(t, os.FileMode(0o600), cmder.modes["/config"])
+	assert.Equal(t, [2]uint32{1000, 1001}, cmder.owners["/config"])
+}
+
+func TestFileTarget_sftpAppend(t *testing.T) {
+	client, _ := newTestSFTPClient(t)
+	target := newTestSFTPFileTarget(t, client)
+
+	writeTestSFTPFile(t, client, "/log", "first\n")
+
+	err := target.Upload(InstanceFileModel{
+		Content:    types.StringValue("second\n"),
+		TargetPath: types.StringValue("/log"),
+		Append:     types.BoolValue(true),
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "first\nsecond\n", readTestSFTPFile(t, client, "/log"))
+}
+
+func TestFileTarget_sftpDeleteMissing(t *testing.T) {
+	client, _ := newTestSFTPClient(t)
+	target := newTestSFTPFileTarget(t, client)
+
+	require.NoError(t, target.Delete("/missing"))
+}
+
+func TestIsFileNotFound(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{
+			name:     "file API not found",
+			err:      api.StatusErrorf(http.StatusNotFound, "not found"),
+			expected: true,
+		},
+		{
+			name:     "wrapped file API not found",
+			err:      fmt.Errorf("Failed to delete file: %w", api.StatusErrorf(http.StatusNotFound, "not found")),
+			expected: true,
+		},
+		{
+			name:     "file API error",
+			err:      api.StatusErrorf(http.StatusInternalServerError, "internal error"),
+			expected: false,
+		},
+		{
+			name:     "SFTP not found",
+			err:      &fs.PathError{Op: "remove", Path: "/missing", Err: fs.ErrNotExist},
+			expected: true,
+		},
+		{
+			name:     "SFTP permission denied",
+			err:      &fs.PathError{Op: "remove", Path: "/root", Err: fs.ErrPermission},
+			expected: false,
+		},
+		{
+			name:     "other error",
+			err:      errors.New("connection reset"),
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, isFileNotFound(tt.err))
+		})
+	}
+}
+
+func TestFileTarget_pushDirectory(t *testing.T) {
+	client, cmder := newTestSFTPClient(t)
+	target := newTestSFTPFileTarget(t, client)
+
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "sub"), 0o755))
+	require.NoError(t, os.Chmod(filepath.Join(dir, "sub"), 0o750))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "sub", "a.txt"), []byte("a"), 0o644))
+
+	file := InstanceFileModel{
+		SourceDir:  types.StringValue(dir),
+		TargetPath: types.StringValue("/app"),
+	}
+
+	require.NoError(t, target.Upload(file))
+	assert.Equal(t, "a", readTestSFTPFile(t, client, "/app/sub/a.txt"))
+	assert.Equal(t, os.FileMode(0o750), cmder.modes["/app/sub"])
+	assert.Equal(t, os.FileMode(0o644), cmder.modes["/app/sub/a.txt"])
+
+	// Mode changes are applied to existing directories.
+	require.NoError(t, os.Chmod(filepath.Join(dir, "sub"), 0o700))
+	require.NoError(t, target.Upload(file))
+	assert.Equal(t, os.FileMode(0o700), cmder.modes["/app/sub"])
+}
+
+func TestFileTarget_pushDirectoryUnchanged(t *testing.T) {
+	client, cmder := newTestSFTPClient(t)
+	target := newTestSFTPFileTarget(t, client)
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.txt"), []byte("a"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "b.txt"), []byte("b"), 0o644))
+
+	// The in-memory server reports this ownership for all files.
+	file := InstanceFileModel{
+		SourceDir:  types.StringValue(dir),
+		TargetPath: types.StringValue("/app"),
+		UserID:     types.Int64Value(65534),
+		GroupID:    types.Int64Value(65534),
+	}
+
+	require.NoError(t, target.Upload(file))
+	assert.Contains(t, cmder.modes, "/app/a.txt")
+	assert.Contains(t, cmder.modes, "/app/b.txt")
+
+	// Only files with changed content are uploaded again.
+	clear(cmder.modes)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "b.txt"), []byte("changed"), 0o644))
+	require.NoError(t, target.Upload(file))
+	assert.NotContains(t, cmder.modes, "/app/a.txt")
+	assert.Contains(t, cmder.modes, "/app/b.txt")
+	assert.Equal(t, "changed", readTestSFTPFile(t, client, "/app/b.txt"))
+
+	// Files are overwritten in place over SFTP.
+	assert.Empty(t, cmder.removed)
+}
+
+func TestFileTarget_pushDirectoryFileAPI(t *testing.T) {
+	type testFile struct {
+		content string
+		mode    int
+	}
+
+	files := map[string]testFile{}
+	var created []string
+	var deleted []string
+
+	target := &FileTarget{
+		api: fileOps{
+			info: func(path string) (*incus.InstanceFileResponse, error) {
+				if path == "/" || path == "/app" {
+					return &incus.InstanceFileResponse{Type: "directory", Mode: 0o755}, nil
+				}
+
+				f, ok := files[path]
+				if !ok {
+					return nil, api.StatusErrorf(http.StatusNotFound, "not found")
+				}
+
+				return &incus.InstanceFileResponse{Type: "file", Mode: f.mode}, nil
+			},
+			read: func(path string) (io.ReadCloser, error) {
+				f, ok := files[path]
+				if !ok {
+					return nil, api.StatusErrorf(http.StatusNotFound, "not found")
+				}
+
+				return io.NopCloser(strings.NewReader(f.content)), nil
+			},
+			create: func(path string, args incus.InstanceFileArgs) error {
+				created = append(created, path)
+				if args.Type != "file" {
+					return nil
+				}
+
+				content, err := io.ReadAll(args.Content)
+				if err != nil {
+					return err
+				}
+
+				files[path] = testFile{content: string(content), mode: args.Mode}
+				return nil
+			},
+			delete: func(path string) error {
+				deleted = append(deleted, path)
+				delete(files, path)
+				return nil
+			},
+		},
+		openSFTP: func() (*sftp.Client, error) {
+			return nil, errors.New("SFTP is not supported")
+		},
+	}
+
+	dir := t.TempDir()
+	require.NoError(t, os.Chmod(dir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.txt"), []byte("a"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "b.txt"), []byte("b"), 0o644))
+
+	file := InstanceFileModel{
+		SourceDir:  types.StringValue(dir),
+		TargetPath: types.StringValue("/app"),
+	}
+
+	require.NoError(t, target.Upload(file))
+	assert.Equal(t, []string{"/app/a.txt", "/app/b.txt"}, created)
+
+	// Unchanged files are skipped, changed ones are replaced.
+	created = nil
+	deleted = nil
+	require.NoError(t, os.Chmod(filepath.Join(dir, "b.txt"), 0o600))
+	require.NoError(t, target.Upload(file))
+	assert.Equal(t, []string{"/app/b.txt"}, created)
+	assert.Equal(t, []string{"/app/b.txt"}, deleted)
+	assert.Equal(t, 0o600, files["/app/b.txt"].mode)
+}
+
+func TestPlanSourceDirHashes_missingDirectory(t *testing.T) {
+	ctx := context.Background()
+	fileType := types.ObjectType{AttrTypes: map[string]attr.Type{
+		"content":            types.StringType,
+		"source_path":        types.StringType,
+		"target_path":        types.StringType,
+		"uid":                types.Int64Type,
+		"gid":                types.Int64Type,
+		"mode":               types.StringType,
+		"directory_mode":     types.StringType,
+		"create_directories": types.BoolType,
+		"append":             types.BoolType,
+		"source_dir":         types.StringType,
+		"delete_removed":     types.BoolType,
+		"source_dir_hash":    types.StringType,
+	}}
+
+	fileSet, diags := types.SetValueFrom(ctx, fileType, []InstanceFileModel{
+		{
+			Content:       types.StringNull(),
+			SourcePath:    types.StringNull(),
+			TargetPath:    types.StringValue("/app"),
+			UserID:        types.Int64Null(),
+			GroupID:       types.Int64Null(),
+			Mode:          types.StringNull(),
+			DirectoryMode: types.StringNull(),
+			CreateDirs:    types.BoolNull(),
+			Append:        types.BoolNull(),
+			SourceDir:     types.StringValue(filepath.Join(t.TempDir(), "missing")),
+			DeleteRemoved: types.BoolNull(),
+			SourceDirHash: types.StringNull(),
+		},
+	})
+	require.False(t, diags.HasError())
+
+	// The directory may not exist yet while planning.
+	planned, diags := PlanSourceDirHashes(ctx, fileSet)
+	require.False(t, diags.HasError())
+
+	files := make([]InstanceFileModel, 0, 1)
+	require.False(t, planned.ElementsAs(ctx, &files, false).HasError())
+	require.Len(t, files, 1)
+	assert.True(t, files[0].SourceDirHash.IsUnknown())
+}
+
+func TestFileTarget_pushDirectoryConflict(t *testing.T) {
+	client, _ := newTestSFTPClient(t)
+	target := newTestSFTPFileTarget(t, client)
+
+	writeTestSFTPFile(t, client, "/app", "not a directory")
+
+	err := target.Upload(InstanceFileModel{
+		SourceDir:  types.StringValue(t.TempDir()),
+		TargetPath: types.StringValue("/app"),
+	})
+	assert.Error(t, err)
+}
@@ -0,0 +1,17 @@
+package common
+
+import (
+	"strings"
+)
+
+// SplitImageRef splits an image reference in the format "[remote:]image"
+// into the remote name and the image alias or fingerprint. If the reference
+// does not contain a remote, the returned remote is empty.
+func SplitImageRef(ref string) (remote string, image string) {
+	parts := strings.SplitN(ref, ":", 2)
+	if len(parts) == 2 {
+		return parts[0], parts[1]
+	}
+
+	return "", ref
+}
@@ -2,6 +2,7 @@ package common
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
@@ -67,6 +68,69 @@ func (m ImportMetadata) ParseImportID(importID string) (map[string]string, diag.
 	return result, nil
 }
 
+// FormatImportID builds an import ID from remote name, project name,
+// required fields, and options, such that it can be parsed again using
+// ParseImportID. Empty remote and project names are omitted. Options are
+// appended in the order of their keys.
+func FormatImportID(remote string, project string, fields []string, options map[string]string) (string, error) {
+	if len(fields) == 0 {
+		return "", fmt.Errorf("Import ID requires at least one field")
+	}
+
+	if strings.ContainsAny(remote, ":/,") {
+		return "", fmt.Errorf("Remote %q must not contain any of %q", remote, ":/,")
+	}
+
+	if strings.ContainsAny(project, "/,") {
+		return "", fmt.Errorf("Project %q must not contain any of %q", project, "/,")
+	}
+
+	for _, field := range fields {
+		if field == "" {
+			return "", fmt.Errorf("Import ID requires non-empty field values")
+		}
+
+		if strings.ContainsAny(field, "/,") {
+			return "", fmt.Errorf("Field %q must not contain any of %q", field, "/,")
+		}
+	}
+
+	id := strings.Join(fields, "/")
+
+	// With multiple required fields, the project separator is mandatory.
+	if project != "" || len(fields) > 1 {
+		id = project + "/" + id
+	}
+
+	// A leading colon is required, if the remaining ID contains a colon,
+	// since it would be taken as remote separator otherwise.
+	if remote != "" || strings.Contains(id, ":") {
+		id = remote + ":" + id
+	}
+
+	keys := make([]string, 0, len(options))
+	for k := range options {
+		keys = append(keys, k)
+	}
+
+	slices.Sort(keys)
+
+	for _, k := range keys {
+		v := options[k]
+		if k == "" {
+			return "", fmt.Errorf("Import ID requires non-empty option keys")
+		}
+
+		if strings.ContainsAny(k, ",=") || strings.ContainsAny(v, ",=") {
+			return "", fmt.Errorf("Option %q must not contain any of %q", k+"="+v, ",=")
+		}
+
+		id += "," + k + "=" + v
+	}
+
+	return id, nil
+}
+
 // processFields convert the mandatory part of the import ID into remote,
 // project, and any number of provided required fields.
 func processFields(id string, requiredFields []string) (map[string]string, error) {
@@ -311,3 +311,121 @@ func TestSplitImportID_ErrorFormat(t *testing.T) {
 		})
 	}
 }
+
+func TestFormatImportID(t *testing.T) {
+	tests := []struct {
+		Remote      string
+		Project     string
+		Fields      []string
+		Options     map[string]string
+		ImportID    string
+		ErrorString string
+	}{
+		{
+			Fields:   []string{"vm"},
+			ImportID: "vm",
+		},
+		{
+			Project:  "proj",
+			Fields:   []string{"vm"},
+			ImportID: "proj/vm",
+		},
+		{
+			Remote:   "rem",
+			Fields:   []string{"vm"},
+			ImportID: "rem:vm",
+		},
+		{
+			Remote:   "rem",
+			Project:  "proj",
+			Fields:   []string{"vm"},
+			ImportID: "rem:proj/vm",
+		},
+		{
+			Fields:   []string{"pool", "vol"},
+			ImportID: "/pool/vol",
+		},
+		{
+			Remote:   "rem",
+			Project:  "proj",
+			Fields:   []string{"pool", "vol"},
+			ImportID: "rem:proj/pool/vol",
+		},
+		{
+			Fields:   []string{"10.0.0.1:80"},
+			ImportID: ":10.0.0.1:80",
+		},
+		{
+			Fields:   []string{"vm"},
+			Options:  map[string]string{"size": "5GiB", "image": "alpine"},
+			ImportID: "vm,image=alpine,size=5GiB",
+		},
+		{
+			ErrorString: "Import ID requires at least one field",
+		},
+		{
+			Fields:      []string{""},
+			ErrorString: "Import ID requires non-empty field values",
+		},
+		{
+			Remote:      "rem:ote",
+			Fields:      []string{"vm"},
+			ErrorString: `Remote "rem:ote" must not contain any of ":/,"`,
+		},
+		{
+			Fields:      []string{"a/b"},
+			ErrorString: `Field "a/b" must not contain any of "/,"`,
+		},
+		{
+			Fields:      []string{"vm"},
+			Options:     map[string]string{"image": "a,b"},
+			ErrorString: `Option "image=a,b" must not contain any of ",="`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("ImportID:%q", test.ImportID), func(t *testing.T) {
+			importID, err := FormatImportID(test.Remote, test.Project, test.Fields, test.Options)
+			if test.ErrorString != "" {
+				assert.EqualError(t, err, test.ErrorString)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.ImportID, importID)
+
+			// Ensure the import ID can be parsed again.
+			fieldNames := make([]string, 0, len(test.Fields))
+			expected := map[string]string{}
+			for i, field := range test.Fields {
+				name := fmt.Sprintf("field%d", i)
+				fieldNames = append(fieldNames, name)
+				expected[name] = field
+			}
+
+			optionNames := make([]string, 0, len(test.Options))
+			for k, v := range test.Options {
+				optionNames = append(optionNames, k)
+				expected[k] = v
+			}
+
+			if test.Remote != "" {
+				expected["remote"] = test.Remote
+			}
+
+			if test.Project != "" {
+				expected["project"] = test.Project
+			}
+
+			meta := ImportMetadata{
+				ResourceName:   "test",
+				RequiredFields: fieldNames,
+				AllowedOptions: optionNames,
+			}
+
+			result, diag := meta.ParseImportID(importID)
+			assert.Nil(t, diag)
+			assert.Equal(t, expected, result)
+		})
+	}
+}
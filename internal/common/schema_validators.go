@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/lxc/incus/v7/shared/osarch"
@@ -36,3 +37,28 @@ func (v ArchitectureValidator) ValidateString(ctx context.Context, req validator
 		v.Description(ctx),
 	)
 }
+
+// TimestampValidator ensures the value is a valid RFC3339 timestamp.
+type TimestampValidator struct{}
+
+func (v TimestampValidator) Description(ctx context.Context) string {
+	return "Attribute value must be a valid RFC3339 timestamp, e.g. 2030-01-01T00:00:00Z."
+}
+
+func (v TimestampValidator) MarkdownDescription(ctx context.Context) string {
+	return "Attribute value must be a valid RFC3339 timestamp, e.g. `2030-01-01T00:00:00Z`."
+}
+
+func (v TimestampValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	value := req.ConfigValue.ValueString()
+	if value == "" {
+		return
+	}
+
+	_, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid timestamp",
+			fmt.Sprintf("%s Got: %q.", v.Description(ctx), value),
+		)
+	}
+}
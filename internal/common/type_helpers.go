@@ -1,6 +1,11 @@
 package common
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
 
 func ToMapStringAnySlice(in any) ([]map[string]any, error) {
 	data, err := json.Marshal(in)
@@ -25,3 +30,35 @@ func ToMapStringAny(in any) (map[string]any, error) {
 	err = json.Unmarshal(data, &value)
 	return value, err
 }
+
+// ToTimestamp converts a Terraform string holding an RFC3339 timestamp into
+// a time. Null, unknown or empty values result in nil.
+func ToTimestamp(value types.String) (*time.Time, error) {
+	if value.IsNull() || value.IsUnknown() || value.ValueString() == "" {
+		return nil, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value.ValueString())
+	if err != nil {
+		return nil, err
+	}
+
+	return &t, nil
+}
+
+// ToTimestampType converts a time into a Terraform string in RFC3339 format.
+// If the current value represents the same point in time, it is returned
+// as is to avoid differences caused by formatting only. A nil or zero time
+// results in a null value.
+func ToTimestampType(t *time.Time, current types.String) types.String {
+	if t == nil || t.IsZero() {
+		return types.StringNull()
+	}
+
+	currentTime, err := ToTimestamp(current)
+	if err == nil && currentTime != nil && currentTime.Equal(*t) {
+		return current
+	}
+
+	return types.StringValue(t.UTC().Format(time.RFC3339))
+}
@@ -0,0 +1,78 @@
+package functions
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/lxc/terraform-provider-incus/internal/common"
+)
+
+type FormatImportIDFunction struct{}
+
+func NewFormatImportIDFunction() function.Function {
+	return &FormatImportIDFunction{}
+}
+
+func (f *FormatImportIDFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "format_import_id"
+}
+
+func (f *FormatImportIDFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Build an import ID",
+		Description: "Builds an import ID in the format [remote:][project/]field1[/fieldN][,key=value], as accepted by the import of the resources of this provider.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:           "remote",
+				Description:    "Name of the remote. Omitted from the import ID, if null or empty.",
+				AllowNullValue: true,
+			},
+			function.StringParameter{
+				Name:           "project",
+				Description:    "Name of the project. Omitted from the import ID, if null or empty.",
+				AllowNullValue: true,
+			},
+			function.ListParameter{
+				Name:        "fields",
+				Description: "Values of the fields required by the resource, e.g. the name of the pool and the volume.",
+				ElementType: types.StringType,
+			},
+			function.MapParameter{
+				Name:           "options",
+				Description:    "Options supported by the resource, e.g. image. Omitted from the import ID, if null or empty.",
+				ElementType:    types.StringType,
+				AllowNullValue: true,
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *FormatImportIDFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var remote types.String
+	var project types.String
+	var fields []string
+	var optionsMap types.Map
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &remote, &project, &fields, &optionsMap))
+	if resp.Error != nil {
+		return
+	}
+
+	options := make(map[string]string, len(optionsMap.Elements()))
+	diags := optionsMap.ElementsAs(ctx, &options, false)
+	if diags.HasError() {
+		resp.Error = function.FuncErrorFromDiags(ctx, diags)
+		return
+	}
+
+	importID, err := common.FormatImportID(remote.ValueString(), project.ValueString(), fields, options)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, importID))
+}
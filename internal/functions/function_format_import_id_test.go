@@ -0,0 +1,63 @@
+package functions_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+
+	"github.com/lxc/terraform-provider-incus/internal/acctest"
+)
+
+func TestAccFormatImportIDFunction_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "name_only" {
+  value = provider::incus::format_import_id(null, null, ["c1"], null)
+}
+
+output "full" {
+  value = provider::incus::format_import_id("local", "default", ["c1"], { image = "images:debian/12", ephemeral = "true" })
+}
+
+output "volume" {
+  value = provider::incus::format_import_id("", "", ["default", "v1"], {})
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckOutput("name_only", "c1"),
+					resource.TestCheckOutput("full", "local:default/c1,ephemeral=true,image=images:debian/12"),
+					resource.TestCheckOutput("volume", "/default/v1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccFormatImportIDFunction_invalid(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "id" {
+  value = provider::incus::format_import_id(null, null, [], null)
+}
+`,
+				ExpectError: regexp.MustCompile(`Import ID requires at least one field`),
+			},
+		},
+	})
+}
@@ -0,0 +1,71 @@
+package functions
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/lxc/terraform-provider-incus/internal/common"
+)
+
+type ParseImageRefFunction struct{}
+
+func NewParseImageRefFunction() function.Function {
+	return &ParseImageRefFunction{}
+}
+
+func (f *ParseImageRefFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_image_ref"
+}
+
+func (f *ParseImageRefFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Split an image reference into remote and alias",
+		Description: "Splits an image reference in the format [remote:]image, as used by the image attribute of incus_instance, into the remote and the image alias or fingerprint. The remote is empty, if the reference does not contain one.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "image",
+				Description: "Image reference, e.g. images:debian/12.",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: imageRefAttrTypes(),
+		},
+	}
+}
+
+func (f *ParseImageRefFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var ref string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &ref))
+	if resp.Error != nil {
+		return
+	}
+
+	if ref == "" {
+		resp.Error = function.NewArgumentFuncError(0, "Image reference must not be empty")
+		return
+	}
+
+	remote, image := common.SplitImageRef(ref)
+
+	result, diags := types.ObjectValue(imageRefAttrTypes(), map[string]attr.Value{
+		"remote": types.StringValue(remote),
+		"alias":  types.StringValue(image),
+	})
+	if diags.HasError() {
+		resp.Error = function.FuncErrorFromDiags(ctx, diags)
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, result))
+}
+
+func imageRefAttrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"remote": types.StringType,
+		"alias":  types.StringType,
+	}
+}
@@ -0,0 +1,73 @@
+package functions_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+
+	"github.com/lxc/terraform-provider-incus/internal/acctest"
+)
+
+func TestAccParseImageRefFunction_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+locals {
+  with_remote    = provider::incus::parse_image_ref("images:debian/12")
+  without_remote = provider::incus::parse_image_ref("debian/12")
+}
+
+output "with_remote_remote" {
+  value = local.with_remote.remote
+}
+
+output "with_remote_alias" {
+  value = local.with_remote.alias
+}
+
+output "without_remote_remote" {
+  value = local.without_remote.remote
+}
+
+output "without_remote_alias" {
+  value = local.without_remote.alias
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckOutput("with_remote_remote", "images"),
+					resource.TestCheckOutput("with_remote_alias", "debian/12"),
+					resource.TestCheckOutput("without_remote_remote", ""),
+					resource.TestCheckOutput("without_remote_alias", "debian/12"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccParseImageRefFunction_empty(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "image" {
+  value = provider::incus::parse_image_ref("")
+}
+`,
+				ExpectError: regexp.MustCompile(`Image reference must not be empty`),
+			},
+		},
+	})
+}
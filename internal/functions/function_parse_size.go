@@ -0,0 +1,50 @@
+package functions
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/lxc/incus/v7/shared/units"
+)
+
+type ParseSizeFunction struct{}
+
+func NewParseSizeFunction() function.Function {
+	return &ParseSizeFunction{}
+}
+
+func (f *ParseSizeFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_size"
+}
+
+func (f *ParseSizeFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Parse a size string into bytes",
+		Description: "Parses a size string as used by Incus, e.g. in limits.memory or the size of a disk device, into the number of bytes. Both decimal (e.g. 2GB) and binary (e.g. 2GiB) suffixes are supported.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "size",
+				Description: "Size string, e.g. 2GiB.",
+			},
+		},
+		Return: function.Int64Return{},
+	}
+}
+
+func (f *ParseSizeFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var size string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &size))
+	if resp.Error != nil {
+		return
+	}
+
+	bytes, err := units.ParseByteSizeString(size)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("Failed to parse size %q: %v", size, err))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, bytes))
+}
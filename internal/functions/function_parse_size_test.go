@@ -0,0 +1,60 @@
+package functions_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+
+	"github.com/lxc/terraform-provider-incus/internal/acctest"
+)
+
+func TestAccParseSizeFunction_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccParseSizeFunction_config("2GiB"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckOutput("size", "2147483648"),
+				),
+			},
+			{
+				Config: testAccParseSizeFunction_config("500MB"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckOutput("size", "500000000"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccParseSizeFunction_invalid(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccParseSizeFunction_config("2XB"),
+				ExpectError: regexp.MustCompile(`Failed to parse size "2XB"`),
+			},
+		},
+	})
+}
+
+func testAccParseSizeFunction_config(size string) string {
+	return fmt.Sprintf(`
+output "size" {
+  value = provider::incus::parse_size(%q)
+}
+`, size)
+}
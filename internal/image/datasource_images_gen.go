@@ -0,0 +1,196 @@
+// Code generated by generate-datasources; DO NOT EDIT.
+
+package image
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/lxc/incus/v7/shared/api"
+
+	"github.com/lxc/terraform-provider-incus/internal/errors"
+	provider_config "github.com/lxc/terraform-provider-incus/internal/provider-config"
+)
+
+type ImagesDataSourceModel struct {
+	Project types.String `tfsdk:"project"`
+	Remote  types.String `tfsdk:"remote"`
+	Filters types.List   `tfsdk:"filters"`
+
+	Images types.List `tfsdk:"images"`
+}
+
+type ImagesDataSource struct {
+	provider *provider_config.IncusProviderConfig
+}
+
+func NewImagesDataSource() datasource.DataSource {
+	return &ImagesDataSource{}
+}
+
+func (d *ImagesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_images", req.ProviderTypeName)
+}
+
+func (d *ImagesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"project": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+
+			"remote": schema.StringAttribute{
+				Optional: true,
+			},
+
+			"filters": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+
+			"images": schema.ListAttribute{
+				Computed:    true,
+				ElementType: getImagesItemObjectType(),
+			},
+		},
+	}
+}
+
+func (d *ImagesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	data := req.ProviderData
+	if data == nil {
+		return
+	}
+
+	provider, ok := data.(*provider_config.IncusProviderConfig)
+	if !ok {
+		resp.Diagnostics.Append(errors.NewProviderDataTypeError(req.ProviderData))
+		return
+	}
+
+	d.provider = provider
+}
+
+func (d *ImagesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state ImagesDataSourceModel
+	var diags diag.Diagnostics
+
+	diags = req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	providerRemote := state.Remote.ValueString()
+	providerProjectName := state.Project.ValueString()
+	providerTarget := ""
+	server, err := d.provider.InstanceServer(providerRemote, providerProjectName, providerTarget)
+	if err != nil {
+		resp.Diagnostics.Append(errors.NewInstanceServerError(err))
+		return
+	}
+
+	filters := make([]string, 0, len(state.Filters.Elements()))
+	diags = state.Filters.ElementsAs(ctx, &filters, false)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	images, err := server.GetImagesWithFilter(filters)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to retrieve images", err.Error())
+		return
+	}
+
+	imagesList := make([]attr.Value, 0, len(images))
+	for _, image := range images {
+		imageValue, diags := toImagesItemObjectValue(ctx, image)
+		resp.Diagnostics.Append(diags...)
+		if diags.HasError() {
+			return
+		}
+
+		imagesList = append(imagesList, imageValue)
+	}
+
+	state.Images, diags = types.ListValue(getImagesItemObjectType(), imagesList)
+	resp.Diagnostics.Append(diags...)
+	if diags.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func getImagesItemObjectType() types.ObjectType {
+	return types.ObjectType{
+		AttrTypes: map[string]attr.Type{
+			"fingerprint":  types.StringType,
+			"type":         types.StringType,
+			"architecture": types.StringType,
+			"public":       types.BoolType,
+			"auto_update":  types.BoolType,
+			"cached":       types.BoolType,
+			"profiles":     getImagesProfilesListType(),
+			"properties":   getImagesPropertiesMapType(),
+		},
+	}
+}
+
+func toImagesItemObjectValue(ctx context.Context, image api.Image) (obj basetypes.ObjectValue, diags diag.Diagnostics) {
+	imagesItemObjectType := getImagesItemObjectType()
+	nilObject := types.ObjectNull(imagesItemObjectType.AttrTypes)
+
+	res := map[string]attr.Value{}
+	res["fingerprint"] = types.StringValue(image.Fingerprint)
+	res["type"] = types.StringValue(image.Type)
+	res["architecture"] = types.StringValue(image.Architecture)
+	res["public"] = types.BoolValue(image.Public)
+	res["auto_update"] = types.BoolValue(image.AutoUpdate)
+	res["cached"] = types.BoolValue(image.Cached)
+
+	res["profiles"], diags = toImagesProfilesListTypeValue(ctx, image.Profiles)
+	if diags.HasError() {
+		return nilObject, diags
+	}
+
+	res["properties"], diags = toImagesPropertiesMapTypeValue(ctx, image.Properties)
+	if diags.HasError() {
+		return nilObject, diags
+	}
+
+	return types.ObjectValue(imagesItemObjectType.AttrTypes, res)
+}
+
+func getImagesProfilesListType() types.ListType {
+	return types.ListType{
+		ElemType: types.StringType,
+	}
+}
+
+func toImagesProfilesListTypeValue(ctx context.Context, in any) (types.List, diag.Diagnostics) {
+	return types.ListValueFrom(ctx, types.StringType, in)
+}
+
+func getImagesPropertiesMapType() types.MapType {
+	return types.MapType{
+		ElemType: types.StringType,
+	}
+}
+
+func toImagesPropertiesMapTypeValue(ctx context.Context, in any) (types.Map, diag.Diagnostics) {
+	return types.MapValueFrom(ctx, types.StringType, in)
+}
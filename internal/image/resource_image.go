@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
@@ -36,12 +37,13 @@ import (
 
 // ImageModel resource data model that matches the schema.
 type ImageModel struct {
-	SourceFile     types.Object `tfsdk:"source_file"`
-	SourceImage    types.Object `tfsdk:"source_image"`
-	SourceInstance types.Object `tfsdk:"source_instance"`
-	Alias          types.Set    `tfsdk:"alias"`
-	Project        types.String `tfsdk:"project"`
-	Remote         types.String `tfsdk:"remote"`
+	SourceFile     types.Object   `tfsdk:"source_file"`
+	SourceImage    types.Object   `tfsdk:"source_image"`
+	SourceInstance types.Object   `tfsdk:"source_instance"`
+	Alias          types.Set      `tfsdk:"alias"`
+	Project        types.String   `tfsdk:"project"`
+	Remote         types.String   `tfsdk:"remote"`
+	Timeouts       timeouts.Value `tfsdk:"timeouts"`
 
 	// Computed.
 	ResourceID    types.String `tfsdk:"resource_id"`
@@ -87,7 +89,7 @@ func (r ImageResource) Metadata(_ context.Context, req resource.MetadataRequest,
 	resp.TypeName = fmt.Sprintf("%s_image", req.ProviderTypeName)
 }
 
-func (r ImageResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r ImageResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"source_file": schema.SingleNestedAttribute{
@@ -239,6 +241,12 @@ func (r ImageResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 					},
 				},
 			},
+
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Delete: true,
+			}),
 		},
 	}
 }
@@ -289,6 +297,15 @@ func (r ImageResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, 0)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := utils.ContextWithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	if !plan.SourceFile.IsNull() {
 		r.createImageFromSourceFile(ctx, resp, &plan)
 		return
@@ -333,6 +350,15 @@ func (r ImageResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
+	updateTimeout, diags := plan.Timeouts.Update(ctx, 0)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := utils.ContextWithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	remote := plan.Remote.ValueString()
 	project := plan.Project.ValueString()
 	server, err := r.provider.InstanceServer(remote, project, "")
@@ -404,6 +430,15 @@ func (r ImageResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		return
 	}
 
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, 0)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := utils.ContextWithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	remote := state.Remote.ValueString()
 	project := state.Project.ValueString()
 	server, err := r.provider.InstanceServer(remote, project, "")
@@ -420,7 +455,7 @@ func (r ImageResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		return
 	}
 
-	err = opDelete.Wait()
+	err = opDelete.WaitContext(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError(fmt.Sprintf("Failed to remove cached image with fingerprint %q", imageFingerprint), err.Error())
 		return
@@ -615,7 +650,7 @@ func (r ImageResource) createImageFromSourceFile(ctx context.Context, resp *reso
 	}
 
 	// Wait for image create operation to finish.
-	err = op.Wait()
+	err = op.WaitContext(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError(fmt.Sprintf("Failed to create image from file %q", dataPath), err.Error())
 		return
@@ -746,7 +781,7 @@ func (r ImageResource) createImageFromSourceImage(ctx context.Context, resp *res
 	}
 
 	// Wait for copy operation to finish.
-	err = opCopy.Wait()
+	err = opCopy.WaitContext(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError(fmt.Sprintf("Failed to copy image %q", image), err.Error())
 		return
@@ -861,7 +896,7 @@ func (r ImageResource) createImageFromSourceInstance(ctx context.Context, resp *
 	}
 
 	// Wait for create operation to finish.
-	err = op.Wait()
+	err = op.WaitContext(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError(fmt.Sprintf("Failed to publish instance %q image", instanceName), err.Error())
 		return
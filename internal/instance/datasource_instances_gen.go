@@ -0,0 +1,207 @@
+// Code generated by generate-datasources; DO NOT EDIT.
+
+package instance
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/lxc/incus/v7/shared/api"
+
+	"github.com/lxc/terraform-provider-incus/internal/common"
+	"github.com/lxc/terraform-provider-incus/internal/errors"
+	provider_config "github.com/lxc/terraform-provider-incus/internal/provider-config"
+)
+
+type InstancesDataSourceModel struct {
+	Project types.String `tfsdk:"project"`
+	Remote  types.String `tfsdk:"remote"`
+	Filters types.List   `tfsdk:"filters"`
+
+	Instances types.List `tfsdk:"instances"`
+}
+
+type InstancesDataSource struct {
+	provider *provider_config.IncusProviderConfig
+}
+
+func NewInstancesDataSource() datasource.DataSource {
+	return &InstancesDataSource{}
+}
+
+func (d *InstancesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_instances", req.ProviderTypeName)
+}
+
+func (d *InstancesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"project": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+
+			"remote": schema.StringAttribute{
+				Optional: true,
+			},
+
+			"filters": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+
+			"instances": schema.ListAttribute{
+				Computed:    true,
+				ElementType: getInstancesItemObjectType(),
+			},
+		},
+	}
+}
+
+func (d *InstancesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	data := req.ProviderData
+	if data == nil {
+		return
+	}
+
+	provider, ok := data.(*provider_config.IncusProviderConfig)
+	if !ok {
+		resp.Diagnostics.Append(errors.NewProviderDataTypeError(req.ProviderData))
+		return
+	}
+
+	d.provider = provider
+}
+
+func (d *InstancesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state InstancesDataSourceModel
+	var diags diag.Diagnostics
+
+	diags = req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	providerRemote := state.Remote.ValueString()
+	providerProjectName := state.Project.ValueString()
+	providerTarget := ""
+	server, err := d.provider.InstanceServer(providerRemote, providerProjectName, providerTarget)
+	if err != nil {
+		resp.Diagnostics.Append(errors.NewInstanceServerError(err))
+		return
+	}
+
+	filters := make([]string, 0, len(state.Filters.Elements()))
+	diags = state.Filters.ElementsAs(ctx, &filters, false)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	instances, err := server.GetInstancesWithFilter(api.InstanceTypeAny, filters)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to retrieve instances", err.Error())
+		return
+	}
+
+	instancesList := make([]attr.Value, 0, len(instances))
+	for _, instance := range instances {
+		instanceValue, diags := toInstancesItemObjectValue(ctx, instance)
+		resp.Diagnostics.Append(diags...)
+		if diags.HasError() {
+			return
+		}
+
+		instancesList = append(instancesList, instanceValue)
+	}
+
+	state.Instances, diags = types.ListValue(getInstancesItemObjectType(), instancesList)
+	resp.Diagnostics.Append(diags...)
+	if diags.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func getInstancesItemObjectType() types.ObjectType {
+	return types.ObjectType{
+		AttrTypes: map[string]attr.Type{
+			"name":        types.StringType,
+			"description": types.StringType,
+			"config": types.MapType{
+				ElemType: types.StringType,
+			},
+			"status":   types.StringType,
+			"location": types.StringType,
+			"device": types.SetType{
+				ElemType: types.ObjectType{
+					AttrTypes: map[string]attr.Type{
+						"name":       types.StringType,
+						"type":       types.StringType,
+						"properties": types.MapType{ElemType: types.StringType},
+					},
+				},
+			},
+			"type":         types.StringType,
+			"architecture": types.StringType,
+			"ephemeral":    types.BoolType,
+			"profiles":     getInstancesProfilesListType(),
+			"stateful":     types.BoolType,
+		},
+	}
+}
+
+func toInstancesItemObjectValue(ctx context.Context, instance api.Instance) (obj basetypes.ObjectValue, diags diag.Diagnostics) {
+	instancesItemObjectType := getInstancesItemObjectType()
+	nilObject := types.ObjectNull(instancesItemObjectType.AttrTypes)
+
+	res := map[string]attr.Value{}
+	res["name"] = types.StringValue(instance.Name)
+	res["description"] = types.StringValue(instance.Description)
+	res["status"] = types.StringValue(instance.Status)
+	res["location"] = types.StringValue(instance.Location)
+	res["type"] = types.StringValue(instance.Type)
+	res["architecture"] = types.StringValue(instance.Architecture)
+	res["ephemeral"] = types.BoolValue(instance.Ephemeral)
+	res["stateful"] = types.BoolValue(instance.Stateful)
+
+	res["config"], diags = types.MapValueFrom(ctx, types.StringType, instance.Config)
+	if diags.HasError() {
+		return nilObject, diags
+	}
+
+	res["device"], diags = common.ToDeviceSetType(ctx, instance.Devices)
+	if diags.HasError() {
+		return nilObject, diags
+	}
+
+	res["profiles"], diags = toInstancesProfilesListTypeValue(ctx, instance.Profiles)
+	if diags.HasError() {
+		return nilObject, diags
+	}
+
+	return types.ObjectValue(instancesItemObjectType.AttrTypes, res)
+}
+
+func getInstancesProfilesListType() types.ListType {
+	return types.ListType{
+		ElemType: types.StringType,
+	}
+}
+
+func toInstancesProfilesListTypeValue(ctx context.Context, in any) (types.List, diag.Diagnostics) {
+	return types.ListValueFrom(ctx, types.StringType, in)
+}
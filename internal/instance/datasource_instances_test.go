@@ -0,0 +1,60 @@
+package instance_test
+
+import (
+	"fmt"
+	"testing"
+
+	petname "github.com/dustinkirkland/golang-petname"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/lxc/terraform-provider-incus/internal/acctest"
+)
+
+func TestAccInstancesDataSource_filters(t *testing.T) {
+	instanceName1 := petname.Generate(2, "-")
+	instanceName2 := petname.Generate(2, "-")
+	role := petname.Generate(2, "-")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccInstancesDataSource_filters(instanceName1, instanceName2, role),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.incus_instances.web", "instances.#", "1"),
+					resource.TestCheckResourceAttr("data.incus_instances.web", "instances.0.name", instanceName1),
+					resource.TestCheckResourceAttr("data.incus_instances.web", "instances.0.status", "Running"),
+					resource.TestCheckResourceAttr("data.incus_instances.web", "instances.0.config.user.role", role),
+				),
+			},
+		},
+	})
+}
+
+func testAccInstancesDataSource_filters(name1 string, name2 string, role string) string {
+	return fmt.Sprintf(`
+resource "incus_instance" "instance1" {
+  name  = "%[1]s"
+  image = "%[4]s"
+
+  config = {
+    "user.role" = "%[3]s"
+  }
+}
+
+resource "incus_instance" "instance2" {
+  name  = "%[2]s"
+  image = "%[4]s"
+}
+
+data "incus_instances" "web" {
+  filters = ["config.user.role=%[3]s"]
+
+  depends_on = [
+    incus_instance.instance1,
+    incus_instance.instance2,
+  ]
+}
+`, name1, name2, role, acctest.TestImage)
+}
@@ -4,12 +4,18 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
+	"regexp"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/boolvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
@@ -31,6 +37,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	incus "github.com/lxc/incus/v7/client"
 	"github.com/lxc/incus/v7/shared/api"
+	incus_shared "github.com/lxc/incus/v7/shared/util"
 	"github.com/mitchellh/go-homedir"
 
 	"github.com/lxc/terraform-provider-incus/internal/common"
@@ -40,24 +47,29 @@ import (
 )
 
 type InstanceModel struct {
-	Name           types.String `tfsdk:"name"`
-	Description    types.String `tfsdk:"description"`
-	Type           types.String `tfsdk:"type"`
-	Image          types.String `tfsdk:"image"`
-	Ephemeral      types.Bool   `tfsdk:"ephemeral"`
-	Running        types.Bool   `tfsdk:"running"`
-	WaitForConfigs types.Set    `tfsdk:"wait_for"`
-	Profiles       types.List   `tfsdk:"profiles"`
-	Devices        types.Set    `tfsdk:"device"`
-	Files          types.Set    `tfsdk:"file"`
-	Exec           types.Map    `tfsdk:"exec"`
-	Config         types.Map    `tfsdk:"config"`
-	Project        types.String `tfsdk:"project"`
-	Remote         types.String `tfsdk:"remote"`
-	Target         types.String `tfsdk:"target"`
-	SourceInstance types.Object `tfsdk:"source_instance"`
-	SourceFile     types.String `tfsdk:"source_file"`
-	Architecture   types.String `tfsdk:"architecture"`
+	Name            types.String   `tfsdk:"name"`
+	Description     types.String   `tfsdk:"description"`
+	Type            types.String   `tfsdk:"type"`
+	Image           types.String   `tfsdk:"image"`
+	Ephemeral       types.Bool     `tfsdk:"ephemeral"`
+	Running         types.Bool     `tfsdk:"running"`
+	DesiredState    types.String   `tfsdk:"desired_state"`
+	StatefulStop    types.Bool     `tfsdk:"stateful_stop"`
+	WaitForConfigs  types.Set      `tfsdk:"wait_for"`
+	Profiles        types.List     `tfsdk:"profiles"`
+	Devices         types.Set      `tfsdk:"device"`
+	Files           types.Set      `tfsdk:"file"`
+	Exec            types.Map      `tfsdk:"exec"`
+	Config          types.Map      `tfsdk:"config"`
+	Project         types.String   `tfsdk:"project"`
+	Remote          types.String   `tfsdk:"remote"`
+	Target          types.String   `tfsdk:"target"`
+	SourceInstance  types.Object   `tfsdk:"source_instance"`
+	SourceFile      types.String   `tfsdk:"source_file"`
+	RestoreSnapshot types.String   `tfsdk:"restore_snapshot"`
+	RestoreTrigger  types.String   `tfsdk:"restore_trigger"`
+	Architecture    types.String   `tfsdk:"architecture"`
+	Timeouts        timeouts.Value `tfsdk:"timeouts"`
 
 	// Computed.
 	IPv4       types.String `tfsdk:"ipv4_address"`
@@ -67,6 +79,27 @@ type InstanceModel struct {
 	Interfaces types.Map    `tfsdk:"interfaces"`
 }
 
+// Power states supported by the "desired_state" attribute.
+const (
+	instanceStateRunning = "running"
+	instanceStateStopped = "stopped"
+	instanceStateFrozen  = "frozen"
+)
+
+// PowerState returns the power state the instance is expected to be in.
+// The "desired_state" attribute takes precedence over "running".
+func (m InstanceModel) PowerState() string {
+	if !m.DesiredState.IsNull() && !m.DesiredState.IsUnknown() {
+		return m.DesiredState.ValueString()
+	}
+
+	if m.Running.IsNull() || m.Running.IsUnknown() || m.Running.ValueBool() {
+		return instanceStateRunning
+	}
+
+	return instanceStateStopped
+}
+
 func (m InstanceModel) IsContainer() bool {
 	return m.Type.ValueString() == "container"
 }
@@ -76,15 +109,23 @@ func (m InstanceModel) IsVirtualMachine() bool {
 }
 
 type SourceInstanceModel struct {
-	Project  types.String `tfsdk:"project"`
-	Name     types.String `tfsdk:"name"`
-	Snapshot types.String `tfsdk:"snapshot"`
+	Remote         types.String `tfsdk:"remote"`
+	Project        types.String `tfsdk:"project"`
+	Name           types.String `tfsdk:"name"`
+	Snapshot       types.String `tfsdk:"snapshot"`
+	Refresh        types.Bool   `tfsdk:"refresh"`
+	RefreshTrigger types.String `tfsdk:"refresh_trigger"`
 }
 
 type WaitForModel struct {
-	Type  types.String `tfsdk:"type"`
-	Delay types.String `tfsdk:"delay"`
-	Nic   types.String `tfsdk:"nic"`
+	Type     types.String `tfsdk:"type"`
+	Delay    types.String `tfsdk:"delay"`
+	Nic      types.String `tfsdk:"nic"`
+	Command  types.List   `tfsdk:"command"`
+	Port     types.Int64  `tfsdk:"port"`
+	Path     types.String `tfsdk:"path"`
+	Timeout  types.String `tfsdk:"timeout"`
+	Interval types.String `tfsdk:"interval"`
 }
 
 func (m WaitForModel) IsAgent() bool {
@@ -111,6 +152,24 @@ func (m WaitForModel) IsCloudInit() bool {
 	return m.Type.ValueString() == "cloud-init"
 }
 
+func (m WaitForModel) IsExec() bool {
+	return m.Type.ValueString() == "exec"
+}
+
+func (m WaitForModel) IsPort() bool {
+	return m.Type.ValueString() == "port"
+}
+
+func (m WaitForModel) IsFile() bool {
+	return m.Type.ValueString() == "file"
+}
+
+// IsCondition returns true if the wait_for type is retried until a condition
+// inside the instance is met.
+func (m WaitForModel) IsCondition() bool {
+	return m.IsExec() || m.IsPort() || m.IsFile()
+}
+
 // InstanceResource represent Incus instance resource.
 type InstanceResource struct {
 	provider *provider_config.IncusProviderConfig
@@ -125,7 +184,7 @@ func (r InstanceResource) Metadata(_ context.Context, req resource.MetadataReque
 	resp.TypeName = fmt.Sprintf("%s_instance", req.ProviderTypeName)
 }
 
-func (r InstanceResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r InstanceResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"name": schema.StringAttribute{
@@ -205,6 +264,21 @@ func (r InstanceResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 				Default:  booldefault.StaticBool(true),
 			},
 
+			"desired_state": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(instanceStateRunning, instanceStateStopped, instanceStateFrozen),
+					stringvalidator.ConflictsWith(path.MatchRoot("running")),
+				},
+			},
+
+			"stateful_stop": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+
 			// If profiles are null, use "default" profile.
 			// If profiles length is 0, no profiles are applied.
 			"profiles": schema.ListAttribute{
@@ -242,7 +316,7 @@ func (r InstanceResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 				Optional: true,
 				Computed: true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplaceIfConfigured(),
+					stringplanmodifier.UseStateForUnknown(),
 				},
 				Validators: []validator.String{
 					stringvalidator.LengthAtLeast(1),
@@ -299,6 +373,22 @@ func (r InstanceResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 								stringvalidator.OneOf("on_change", "once"),
 							},
 						},
+						"allowed_exit_codes": schema.ListAttribute{
+							Optional:    true,
+							ElementType: types.Int64Type,
+						},
+
+						// Computed.
+
+						"stdout": schema.StringAttribute{
+							Computed: true,
+						},
+						"stderr": schema.StringAttribute{
+							Computed: true,
+						},
+						"exit_code": schema.Int64Attribute{
+							Computed: true,
+						},
 					},
 				},
 			},
@@ -306,18 +396,52 @@ func (r InstanceResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 			"source_instance": schema.SingleNestedAttribute{
 				Optional: true,
 				Attributes: map[string]schema.Attribute{
+					"remote": schema.StringAttribute{
+						Optional: true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.RequiresReplace(),
+						},
+					},
 					"project": schema.StringAttribute{
 						Required: true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.RequiresReplace(),
+						},
 					},
 					"name": schema.StringAttribute{
 						Required: true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.RequiresReplace(),
+						},
 					},
 					"snapshot": schema.StringAttribute{
 						Optional: true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.RequiresReplace(),
+						},
+					},
+					"refresh": schema.BoolAttribute{
+						Optional: true,
+						Validators: []validator.Bool{
+							boolvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("snapshot")),
+						},
+					},
+					"refresh_trigger": schema.StringAttribute{
+						Optional: true,
+						Validators: []validator.String{
+							stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("refresh")),
+						},
 					},
 				},
 				PlanModifiers: []planmodifier.Object{
-					objectplanmodifier.RequiresReplace(),
+					// Changes of the refresh settings are applied in place.
+					objectplanmodifier.RequiresReplaceIf(
+						func(ctx context.Context, req planmodifier.ObjectRequest, resp *objectplanmodifier.RequiresReplaceIfFuncResponse) {
+							resp.RequiresReplace = req.StateValue.IsNull() != req.PlanValue.IsNull()
+						},
+						"Adding or removing the source instance requires replacement.",
+						"Adding or removing the source instance requires replacement.",
+					),
 				},
 				Validators: []validator.Object{
 					objectvalidator.ConflictsWith(path.MatchRoot("source_file")),
@@ -335,6 +459,20 @@ func (r InstanceResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 				},
 			},
 
+			"restore_snapshot": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+
+			"restore_trigger": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("restore_snapshot")),
+				},
+			},
+
 			"architecture": schema.StringAttribute{
 				Optional: true,
 				Computed: true,
@@ -424,7 +562,7 @@ func (r InstanceResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 						"type": schema.StringAttribute{
 							Required: true,
 							Validators: []validator.String{
-								stringvalidator.OneOf("agent", "cloud-init", "delay", "ipv4", "ipv6", "ready"),
+								stringvalidator.OneOf("agent", "cloud-init", "delay", "exec", "file", "ipv4", "ipv6", "port", "ready"),
 							},
 						},
 						"delay": schema.StringAttribute{
@@ -433,6 +571,31 @@ func (r InstanceResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 						"nic": schema.StringAttribute{
 							Optional: true,
 						},
+						"command": schema.ListAttribute{
+							Optional:    true,
+							ElementType: types.StringType,
+							Validators: []validator.List{
+								listvalidator.SizeAtLeast(1),
+							},
+						},
+						"port": schema.Int64Attribute{
+							Optional: true,
+							Validators: []validator.Int64{
+								int64validator.Between(1, 65535),
+							},
+						},
+						"path": schema.StringAttribute{
+							Optional: true,
+							Validators: []validator.String{
+								stringvalidator.RegexMatches(absolutePathRegexp, "must be an absolute path"),
+							},
+						},
+						"timeout": schema.StringAttribute{
+							Optional: true,
+						},
+						"interval": schema.StringAttribute{
+							Optional: true,
+						},
 					},
 				},
 			},
@@ -511,6 +674,18 @@ func (r InstanceResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 							Optional: true,
 						},
 
+						"source_dir": schema.StringAttribute{
+							Optional: true,
+						},
+
+						"delete_removed": schema.BoolAttribute{
+							Optional: true,
+						},
+
+						"source_dir_hash": schema.StringAttribute{
+							Computed: true,
+						},
+
 						// Append is here just to satisfy the IncusFile model.
 						"append": schema.BoolAttribute{
 							Computed: true,
@@ -519,6 +694,12 @@ func (r InstanceResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 					},
 				},
 			},
+
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Delete: true,
+			}),
 		},
 	}
 }
@@ -552,6 +733,124 @@ func (r *InstanceResource) ModifyPlan(ctx context.Context, req resource.ModifyPl
 	if profiles.IsNull() {
 		resp.Plan.SetAttribute(ctx, path.Root("profiles"), []string{"default"})
 	}
+
+	// Detect changes to local directory trees pushed using source_dir.
+	var files types.Set
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("file"), &files)...)
+	files, diags := common.PlanSourceDirHashes(ctx, files)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("file"), files)...)
+
+	// Keep "running" and "desired_state" consistent with each other.
+	resp.Diagnostics.Append(modifyPowerStatePlan(ctx, req, resp)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// On update, keep the results of exec entries that are not going to run.
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(modifyExecPlan(ctx, req, resp)...)
+	}
+}
+
+// modifyPowerStatePlan derives the planned "running" value from a configured
+// "desired_state", or the planned "desired_state" from "running" otherwise.
+func modifyPowerStatePlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var desiredState types.String
+	var running types.Bool
+
+	diags.Append(req.Config.GetAttribute(ctx, path.Root("desired_state"), &desiredState)...)
+	diags.Append(resp.Plan.GetAttribute(ctx, path.Root("running"), &running)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	if desiredState.IsUnknown() {
+		return resp.Plan.SetAttribute(ctx, path.Root("running"), types.BoolUnknown())
+	}
+
+	if !desiredState.IsNull() {
+		return resp.Plan.SetAttribute(ctx, path.Root("running"), desiredState.ValueString() == instanceStateRunning)
+	}
+
+	if running.IsUnknown() {
+		return nil
+	}
+
+	if running.ValueBool() {
+		return resp.Plan.SetAttribute(ctx, path.Root("desired_state"), instanceStateRunning)
+	}
+
+	return resp.Plan.SetAttribute(ctx, path.Root("desired_state"), instanceStateStopped)
+}
+
+// modifyExecPlan marks the results of exec entries that are going to run as
+// unknown and keeps the results from state for all other entries.
+func modifyExecPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) diag.Diagnostics {
+	var planExec types.Map
+	var stateExec types.Map
+
+	diags := resp.Plan.GetAttribute(ctx, path.Root("exec"), &planExec)
+	if diags.HasError() || planExec.IsNull() || planExec.IsUnknown() {
+		return diags
+	}
+
+	diags = req.State.GetAttribute(ctx, path.Root("exec"), &stateExec)
+	if diags.HasError() {
+		return diags
+	}
+
+	execPlan, diags := common.ToExecMap(ctx, planExec)
+	if diags.HasError() {
+		return diags
+	}
+
+	execState, diags := common.ToExecMap(ctx, stateExec)
+	if diags.HasError() {
+		return diags
+	}
+
+	runs, diags := collectExecRuns(ctx, execPlan, execState, false)
+	if diags.HasError() {
+		return diags
+	}
+
+	willRun := make(map[string]bool, len(runs))
+	for _, run := range runs {
+		willRun[run.Name] = true
+	}
+
+	for name, exec := range execPlan {
+		stateEntry, ok := execState[name]
+		switch {
+		case willRun[name]:
+			exec.Stdout = types.StringUnknown()
+			exec.Stderr = types.StringUnknown()
+			exec.ExitCode = types.Int64Unknown()
+		case ok:
+			exec.Stdout = stateEntry.Stdout
+			exec.Stderr = stateEntry.Stderr
+			exec.ExitCode = stateEntry.ExitCode
+		default:
+			exec.Stdout = types.StringNull()
+			exec.Stderr = types.StringNull()
+			exec.ExitCode = types.Int64Null()
+		}
+
+		execPlan[name] = exec
+	}
+
+	planExec, diags = common.ToExecMapType(ctx, execPlan)
+	if diags.HasError() {
+		return diags
+	}
+
+	return resp.Plan.SetAttribute(ctx, path.Root("exec"), planExec)
 }
 
 func (r InstanceResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
@@ -587,6 +886,14 @@ func (r InstanceResource) ValidateConfig(ctx context.Context, req resource.Valid
 		)
 	}
 
+	if ephemeral && config.DesiredState.ValueString() == instanceStateStopped {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("desired_state"),
+			fmt.Sprintf("Instance %q is ephemeral and cannot be stopped", config.Name.ValueString()),
+			fmt.Sprintf("Ephemeral instances are removed when stopped, therefore attribute %q cannot be set to %q.", "desired_state", instanceStateStopped),
+		)
+	}
+
 	if !config.SourceFile.IsNull() {
 		// With `incus import`, a storage pool can be provided optionally.
 		// In order to support the same behavior with source_file,
@@ -642,7 +949,7 @@ func (r InstanceResource) ValidateConfig(ctx context.Context, req resource.Valid
 
 	if !config.Files.IsNull() && !config.Files.IsUnknown() {
 		if len(config.Files.Elements()) > 0 {
-			if !config.Running.IsNull() && !config.Running.ValueBool() {
+			if (!config.Running.IsNull() && !config.Running.ValueBool()) || config.DesiredState.ValueString() == instanceStateStopped {
 				resp.Diagnostics.AddError(
 					"Invalid Configuration",
 					"Files can only be pushed to running instances.",
@@ -715,6 +1022,78 @@ func validateWaitFor(ctx context.Context, config InstanceModel, resp *resource.V
 				)
 			}
 		}
+
+		validateWaitForCondition(waitFor, resp)
+	}
+}
+
+// validateWaitForCondition validates the attributes of the wait_for types
+// exec, port and file.
+func validateWaitForCondition(waitFor WaitForModel, resp *resource.ValidateConfigResponse) {
+	attributes := []struct {
+		name     string
+		waitType string
+		isSet    bool
+		isType   bool
+	}{
+		{"command", "exec", !waitFor.Command.IsNull(), waitFor.IsExec()},
+		{"port", "port", !waitFor.Port.IsNull(), waitFor.IsPort()},
+		{"path", "file", !waitFor.Path.IsNull(), waitFor.IsFile()},
+	}
+
+	for _, attribute := range attributes {
+		if attribute.isSet && !attribute.isType {
+			resp.Diagnostics.AddError(
+				"Invalid Configuration",
+				fmt.Sprintf("%q can only be set when type is set to %q.", attribute.name, attribute.waitType),
+			)
+		}
+
+		if !attribute.isSet && attribute.isType {
+			resp.Diagnostics.AddError(
+				"Invalid Configuration",
+				fmt.Sprintf("%q is required when type is set to %q.", attribute.name, attribute.waitType),
+			)
+		}
+	}
+
+	if !waitFor.IsCondition() {
+		if !waitFor.Timeout.IsNull() || !waitFor.Interval.IsNull() {
+			resp.Diagnostics.AddError(
+				"Invalid Configuration",
+				`"timeout" and "interval" can only be set when type is set to "exec", "port" or "file".`,
+			)
+		}
+
+		return
+	}
+
+	if !waitFor.Nic.IsNull() {
+		resp.Diagnostics.AddError(
+			"Invalid Configuration",
+			`"nic" can only be set when type is set to "ipv4" or "ipv6".`,
+		)
+	}
+
+	if !waitFor.Delay.IsNull() {
+		resp.Diagnostics.AddError(
+			"Invalid Configuration",
+			`"delay" can only be set when type is set to "delay".`,
+		)
+	}
+
+	for name, value := range map[string]types.String{"timeout": waitFor.Timeout, "interval": waitFor.Interval} {
+		if value.IsNull() || value.IsUnknown() {
+			continue
+		}
+
+		_, err := time.ParseDuration(value.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Invalid Configuration",
+				fmt.Sprintf("Invalid %q for wait_for type %q: %v", name, waitFor.Type.ValueString(), err),
+			)
+		}
 	}
 }
 
@@ -727,7 +1106,7 @@ func validateExec(ctx context.Context, config InstanceModel, resp *resource.Vali
 	}
 
 	if len(execMap) > 0 {
-		if !config.Running.IsNull() && !config.Running.ValueBool() {
+		if (!config.Running.IsNull() && !config.Running.ValueBool()) || config.DesiredState.ValueString() == instanceStateStopped {
 			resp.Diagnostics.AddError(
 				"Invalid Configuration",
 				"Exec commands can only be run on running instances.",
@@ -779,6 +1158,15 @@ func (r InstanceResource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, 0)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := utils.ContextWithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	remote := plan.Remote.ValueString()
 	project := plan.Project.ValueString()
 	target := plan.Target.ValueString()
@@ -798,7 +1186,7 @@ func (r InstanceResource) Create(ctx context.Context, req resource.CreateRequest
 		diags = r.createInstanceFromSourceInstance(ctx, server, plan)
 		resp.Diagnostics.Append(diags...)
 	} else {
-		if plan.Running.ValueBool() && plan.Type.ValueString() != "virtual-machine" {
+		if plan.PowerState() != instanceStateStopped && plan.Type.ValueString() != "virtual-machine" {
 			resp.Diagnostics.AddError("running must be set to false if the instance is created without image or source_instance and not type virtual-machine", "")
 			return
 		}
@@ -814,16 +1202,34 @@ func (r InstanceResource) Create(ctx context.Context, req resource.CreateRequest
 	instanceName := plan.Name.ValueString()
 
 	// Update Terraform state early to ensure the instance can still be
-	// reconciled or destroyed if subsequent wait operations fail.
-	diags = r.SyncState(ctx, &resp.State, server, plan)
+	// reconciled or destroyed if subsequent wait operations fail. Exec
+	// commands have not run yet, so their results are stored as null.
+	earlyState := plan
+	if !plan.Exec.IsNull() && !plan.Exec.IsUnknown() {
+		execPlan, diags := common.ToExecMap(ctx, plan.Exec)
+		if diags.HasError() {
+			resp.Diagnostics.Append(diags...)
+			return
+		}
+
+		resolveExecResults(execPlan)
+		earlyState.Exec, diags = common.ToExecMapType(ctx, execPlan)
+		if diags.HasError() {
+			resp.Diagnostics.Append(diags...)
+			return
+		}
+	}
+
+	diags = r.SyncState(ctx, &resp.State, server, earlyState)
 	if diags.HasError() {
 		resp.Diagnostics.Append(diags...)
 		return
 	}
 
 	// We must ensure that the instance is running before we can upload files.
-	if plan.Running.ValueBool() || (!plan.Files.IsNull() && !plan.Files.IsUnknown()) {
-		diag := startInstance(ctx, server, instanceName)
+	// Instances that are expected to be frozen are frozen once provisioned.
+	if plan.PowerState() != instanceStateStopped || (!plan.Files.IsNull() && !plan.Files.IsUnknown()) {
+		diag := startInstance(ctx, server, instanceName, false)
 		if diag != nil {
 			resp.Diagnostics.Append(diag)
 			return
@@ -847,8 +1253,11 @@ func (r InstanceResource) Create(ctx context.Context, req resource.CreateRequest
 			return
 		}
 
+		fileTarget := common.NewInstanceFileTarget(server, instanceName)
+		defer fileTarget.Close()
+
 		for _, f := range files {
-			err := common.InstanceFileUpload(server, instanceName, f)
+			err := fileTarget.Upload(f)
 			if err != nil {
 				resp.Diagnostics.AddError(fmt.Sprintf("Failed to upload file to instance %q", instanceName), err.Error())
 				return
@@ -880,17 +1289,28 @@ func (r InstanceResource) Create(ctx context.Context, req resource.CreateRequest
 					}
 				}
 
-				for _, run := range runs {
-					stdout, stderr, err := common.RunInstanceExec(ctx, server, instanceName, run.ExecConfig)
-					if err != nil {
-						resp.Diagnostics.AddError(
-							fmt.Sprintf("Failed to execute command %q in instance %q", run.Name, instanceName),
-							formatExecError(err, stdout, stderr),
-						)
-						return
-					}
+				diags = runExecs(ctx, server, instanceName, runs, execPlan)
+				if diags.HasError() {
+					resp.Diagnostics.Append(diags...)
+					return
 				}
 			}
+
+			resolveExecResults(execPlan)
+			plan.Exec, diags = common.ToExecMapType(ctx, execPlan)
+			if diags.HasError() {
+				resp.Diagnostics.Append(diags...)
+				return
+			}
+		}
+	}
+
+	// Freeze the instance if its desired state is frozen.
+	if plan.PowerState() == instanceStateFrozen {
+		diag := freezeInstance(ctx, server, instanceName)
+		if diag != nil {
+			resp.Diagnostics.Append(diag)
+			return
 		}
 	}
 
@@ -925,9 +1345,10 @@ func (r InstanceResource) Read(ctx context.Context, req resource.ReadRequest, re
 // Update updates the instance in the following order:
 // - Stop the instance if its desired state is stopped.
 // - Update configuration (config, devices, profiles).
-// - Start the instance if its desired state is running.
+// - Start the instance if its desired state is running or frozen.
 // - Upload files.
 // - Run exec commands.
+// - Freeze the instance if its desired state is frozen.
 func (r InstanceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	var plan InstanceModel
 	var state InstanceModel
@@ -943,6 +1364,15 @@ func (r InstanceResource) Update(ctx context.Context, req resource.UpdateRequest
 		return
 	}
 
+	updateTimeout, diags := plan.Timeouts.Update(ctx, 0)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := utils.ContextWithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	remote := plan.Remote.ValueString()
 	project := plan.Project.ValueString()
 	target := plan.Target.ValueString()
@@ -959,25 +1389,76 @@ func (r InstanceResource) Update(ctx context.Context, req resource.UpdateRequest
 		return
 	}
 
+	powerState := plan.PowerState()
+	stateful := plan.StatefulStop.ValueBool()
+
 	// Stop before applying configuration changes if the desired state is stopped.
-	if !plan.Running.ValueBool() && !isInstanceStopped(*instanceState) {
+	if powerState == instanceStateStopped && !isInstanceStopped(*instanceState) {
 		// Stop the instance gracefully.
-		_, diag := stopInstance(ctx, server, instanceName, false)
+		_, diag := stopInstance(ctx, server, instanceName, false, stateful)
 		if diag != nil {
 			resp.Diagnostics.Append(diag)
 			return
 		}
 	}
 
-	// Get instance.
-	instance, etag, err := server.GetInstance(instanceName)
-	if err != nil {
-		resp.Diagnostics.AddError(fmt.Sprintf("Failed to retrieve existing instance %q", instanceName), err.Error())
-		return
+	// Move the instance to the new cluster member or group.
+	newTarget := plan.Target.ValueString()
+	if !plan.Target.IsUnknown() && newTarget != "" && newTarget != state.Target.ValueString() {
+		diags := migrateInstance(ctx, server, instanceName, newTarget)
+		if diags.HasError() {
+			resp.Diagnostics.Append(diags...)
+			return
+		}
 	}
 
-	// First extract profiles, devices, config and config state.
-	// Then merge user defined config with instance config (state).
+	// Refresh the instance from its source instance.
+	refresh, diags := sourceInstanceRefreshRequired(ctx, plan, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if refresh {
+		diags := r.refreshInstanceFromSourceInstance(ctx, server, plan)
+		if diags.HasError() {
+			resp.Diagnostics.Append(diags...)
+			return
+		}
+
+		// The instance is stopped during the refresh.
+		instanceState, _, err = server.GetInstanceState(instanceName)
+		if err != nil {
+			resp.Diagnostics.AddError(fmt.Sprintf("Failed to retrieve state of instance %q", instanceName), err.Error())
+			return
+		}
+	}
+
+	// Roll the instance back to a snapshot.
+	if snapshotRestoreRequired(plan, state) {
+		diags := restoreInstanceSnapshot(ctx, server, instanceName, plan.RestoreSnapshot.ValueString())
+		if diags.HasError() {
+			resp.Diagnostics.Append(diags...)
+			return
+		}
+
+		// The instance is stopped during the restore.
+		instanceState, _, err = server.GetInstanceState(instanceName)
+		if err != nil {
+			resp.Diagnostics.AddError(fmt.Sprintf("Failed to retrieve state of instance %q", instanceName), err.Error())
+			return
+		}
+	}
+
+	// Get instance.
+	instance, etag, err := server.GetInstance(instanceName)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to retrieve existing instance %q", instanceName), err.Error())
+		return
+	}
+
+	// First extract profiles, devices, config and config state.
+	// Then merge user defined config with instance config (state).
 	profiles, diags := ToProfileList(ctx, plan.Profiles)
 	resp.Diagnostics.Append(diags...)
 
@@ -1008,7 +1489,7 @@ func (r InstanceResource) Update(ctx context.Context, req resource.UpdateRequest
 	opUpdate, err := server.UpdateInstance(instanceName, newInstance, etag)
 	if err == nil {
 		// Wait for the instance to be updated.
-		err = opUpdate.Wait()
+		err = opUpdate.WaitContext(ctx)
 	}
 
 	if err != nil {
@@ -1016,11 +1497,17 @@ func (r InstanceResource) Update(ctx context.Context, req resource.UpdateRequest
 		return
 	}
 
-	// Start after applying configuration changes if the desired state is running.
-	// Some updates, such as adding a TPM device to a VM, require the instance to
-	// remain stopped while the change is applied.
-	if plan.Running.ValueBool() && !isInstanceOperational(*instanceState) {
-		diag := startInstance(ctx, server, instanceName)
+	// Start after applying configuration changes if the desired state is running
+	// or frozen. Some updates, such as adding a TPM device to a VM, require the
+	// instance to remain stopped while the change is applied. A frozen instance
+	// is only thawed if files have to be pushed or exec commands have to be run.
+	start := powerState != instanceStateStopped && !isInstanceOperational(*instanceState)
+	if powerState == instanceStateFrozen && isInstanceFrozen(*instanceState) {
+		start = !plan.Files.Equal(state.Files) || !plan.Exec.Equal(state.Exec)
+	}
+
+	if start {
+		diag := startInstance(ctx, server, instanceName, stateful)
 		if diag != nil {
 			resp.Diagnostics.Append(diag)
 			return
@@ -1046,15 +1533,19 @@ func (r InstanceResource) Update(ctx context.Context, req resource.UpdateRequest
 		return
 	}
 
-	// Remove files that are no longer present in newFiles.
+	fileTarget := common.NewInstanceFileTarget(server, instanceName)
+	defer fileTarget.Close()
+
+	// Remove files that are no longer present in newFiles. Directory trees
+	// are kept, as the target directory may contain other files.
 	for k, f := range oldFiles {
 		_, ok := newFiles[k]
-		if ok {
+		if ok || f.IsDirectory() {
 			continue
 		}
 
 		targetPath := f.TargetPath.ValueString()
-		err := common.InstanceFileDelete(server, instanceName, targetPath)
+		err := fileTarget.Delete(targetPath)
 		if err != nil {
 			resp.Diagnostics.AddError(fmt.Sprintf("Failed to delete file from instance %q", instanceName), err.Error())
 			return
@@ -1066,7 +1557,7 @@ func (r InstanceResource) Update(ctx context.Context, req resource.UpdateRequest
 		oldFile, exists := oldFiles[k]
 
 		if !exists {
-			err := common.InstanceFileUpload(server, instanceName, newFile)
+			err := fileTarget.Upload(newFile)
 			if err != nil {
 				resp.Diagnostics.AddError(fmt.Sprintf("Failed to upload file to instance %q", instanceName), err.Error())
 				return
@@ -1077,17 +1568,24 @@ func (r InstanceResource) Update(ctx context.Context, req resource.UpdateRequest
 		contentChanged := common.HasFileContentChanged(newFile, oldFile)
 		permissionsChanged := common.HasFilePermissionChanged(newFile, oldFile)
 
-		if contentChanged || permissionsChanged {
+		if (contentChanged || permissionsChanged) && newFile.IsDirectory() {
+			// Directory trees are synced in place.
+			err := fileTarget.Upload(newFile)
+			if err != nil {
+				resp.Diagnostics.AddError(fmt.Sprintf("Failed to upload updated file to instance %q", instanceName), err.Error())
+				return
+			}
+		} else if contentChanged || permissionsChanged {
 			// Delete the old file first otherwise mode and ownership changes
 			// will not be applied.
 			targetPath := newFile.TargetPath.ValueString()
-			err := common.InstanceFileDelete(server, instanceName, targetPath)
+			err := fileTarget.Delete(targetPath)
 			if err != nil {
 				resp.Diagnostics.AddError(fmt.Sprintf("Failed to delete file from instance %q", instanceName), err.Error())
 				return
 			}
 
-			err = common.InstanceFileUpload(server, instanceName, newFile)
+			err = fileTarget.Upload(newFile)
 			if err != nil {
 				resp.Diagnostics.AddError(fmt.Sprintf("Failed to upload updated file to instance %q", instanceName), err.Error())
 				return
@@ -1125,17 +1623,19 @@ func (r InstanceResource) Update(ctx context.Context, req resource.UpdateRequest
 					}
 				}
 
-				for _, run := range runs {
-					stdout, stderr, err := common.RunInstanceExec(ctx, server, instanceName, run.ExecConfig)
-					if err != nil {
-						resp.Diagnostics.AddError(
-							fmt.Sprintf("Failed to execute command %q in instance %q", run.Name, instanceName),
-							formatExecError(err, stdout, stderr),
-						)
-						return
-					}
+				diags = runExecs(ctx, server, instanceName, runs, execPlan)
+				if diags.HasError() {
+					resp.Diagnostics.Append(diags...)
+					return
 				}
 			}
+
+			resolveExecResults(execPlan)
+			plan.Exec, diags = common.ToExecMapType(ctx, execPlan)
+			if diags.HasError() {
+				resp.Diagnostics.Append(diags...)
+				return
+			}
 		}
 	}
 
@@ -1156,7 +1656,7 @@ func (r InstanceResource) Update(ctx context.Context, req resource.UpdateRequest
 		// Stop instance if it's running (required for rename operation)
 		if !isInstanceStopped(*instanceState) {
 			// Stop the instance gracefully.
-			_, diag := stopInstance(ctx, server, instanceName, false)
+			_, diag := stopInstance(ctx, server, instanceName, false, false)
 			if diag != nil {
 				resp.Diagnostics.Append(diag)
 				return
@@ -1166,7 +1666,7 @@ func (r InstanceResource) Update(ctx context.Context, req resource.UpdateRequest
 		opUpdate, err = server.RenameInstance(instanceName, renameInstance)
 		if err == nil {
 			// Wait for the instance to be updated.
-			err = opUpdate.Wait()
+			err = opUpdate.WaitContext(ctx)
 		}
 
 		if err != nil {
@@ -1175,8 +1675,8 @@ func (r InstanceResource) Update(ctx context.Context, req resource.UpdateRequest
 		}
 
 		// Restore instance to its original running state if it was operational before rename
-		if isInstanceOperational(*instanceState) {
-			diag := startInstance(ctx, server, newInstanceName)
+		if isInstanceOperational(*instanceState) || isInstanceFrozen(*instanceState) {
+			diag := startInstance(ctx, server, newInstanceName, false)
 			if diag != nil {
 				resp.Diagnostics.Append(diag)
 				return
@@ -1184,6 +1684,15 @@ func (r InstanceResource) Update(ctx context.Context, req resource.UpdateRequest
 		}
 	}
 
+	// Freeze the instance if its desired state is frozen.
+	if powerState == instanceStateFrozen {
+		diag := freezeInstance(ctx, server, newInstanceName)
+		if diag != nil {
+			resp.Diagnostics.Append(diag)
+			return
+		}
+	}
+
 	// Update Terraform state.
 	diags = r.SyncState(ctx, &resp.State, server, plan)
 	resp.Diagnostics.Append(diags...)
@@ -1255,6 +1764,43 @@ func shouldRunExec(planExecConfig common.InstanceExecConfig, stateExecConfig com
 	}
 }
 
+// runExecs runs the given exec entries in order and stores their results in
+// execPlan.
+func runExecs(ctx context.Context, server incus.InstanceServer, instanceName string, runs []execRun, execPlan map[string]common.InstanceExecModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	for _, run := range runs {
+		result, err := common.RunInstanceExec(ctx, server, instanceName, run.ExecConfig)
+		if err != nil {
+			diags.AddError(
+				fmt.Sprintf("Failed to execute command %q in instance %q", run.Name, instanceName),
+				formatExecError(err, result.Stdout, result.Stderr),
+			)
+			return diags
+		}
+
+		exec := execPlan[run.Name]
+		exec.SetResult(result)
+		execPlan[run.Name] = exec
+	}
+
+	return diags
+}
+
+// resolveExecResults replaces unknown results of exec entries that were not
+// run with null values. Entries that were not run during an update already
+// carry their previous results from the plan.
+func resolveExecResults(execPlan map[string]common.InstanceExecModel) {
+	for name, exec := range execPlan {
+		if exec.Stdout.IsUnknown() || exec.Stderr.IsUnknown() || exec.ExitCode.IsUnknown() {
+			exec.Stdout = types.StringNull()
+			exec.Stderr = types.StringNull()
+			exec.ExitCode = types.Int64Null()
+			execPlan[name] = exec
+		}
+	}
+}
+
 func formatExecError(err error, stdout string, stderr string) string {
 	message := err.Error()
 	stdout = strings.TrimSpace(stdout)
@@ -1281,6 +1827,15 @@ func (r InstanceResource) Delete(ctx context.Context, req resource.DeleteRequest
 		return
 	}
 
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, 0)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := utils.ContextWithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	remote := state.Remote.ValueString()
 	project := state.Project.ValueString()
 	target := state.Target.ValueString()
@@ -1293,7 +1848,7 @@ func (r InstanceResource) Delete(ctx context.Context, req resource.DeleteRequest
 	instanceName := state.Name.ValueString()
 
 	// Force stop the instance, because we are deleting it anyway.
-	isFound, diag := stopInstance(ctx, server, instanceName, true)
+	isFound, diag := stopInstance(ctx, server, instanceName, true, false)
 	if diag != nil {
 		// Ephemeral instances will be removed when stopped.
 		if !isFound {
@@ -1445,6 +2000,11 @@ func (r InstanceResource) SyncState(ctx context.Context, tfState *tfsdk.State, s
 	// This way, terraform will detect the change if the current status
 	// does not match the expected one.
 	m.Running = types.BoolValue(isInstanceRunning(*instanceState))
+	m.DesiredState = types.StringValue(instancePowerState(*instanceState))
+
+	if m.StatefulStop.IsNull() {
+		m.StatefulStop = types.BoolValue(false)
+	}
 
 	plannedTarget := m.Target.ValueString()
 	actualTarget := ""
@@ -1472,13 +2032,7 @@ func (r InstanceResource) createInstanceFromImage(ctx context.Context, server in
 		return diags
 	}
 
-	image := plan.Image.ValueString()
-	imageRemote := ""
-	imageParts := strings.SplitN(image, ":", 2)
-	if len(imageParts) == 2 {
-		imageRemote = imageParts[0]
-		image = imageParts[1]
-	}
+	imageRemote, image := common.SplitImageRef(plan.Image.ValueString())
 
 	var imageServer incus.ImageServer
 	if imageRemote == "" {
@@ -1520,7 +2074,7 @@ func (r InstanceResource) createInstanceFromImage(ctx context.Context, server in
 	// Initialize the instance. Instance will not be running after this call.
 	if err == nil {
 		// Wait for the instance to be created.
-		err = opCreate.Wait()
+		err = opCreate.WaitContext(ctx)
 	}
 
 	if err != nil {
@@ -1580,7 +2134,7 @@ func (r InstanceResource) createInstanceFromSourceFile(ctx context.Context, serv
 
 	op, err := server.CreateInstanceFromBackup(createArgs)
 	if err == nil {
-		err = op.Wait()
+		err = op.WaitContext(ctx)
 	}
 
 	if err != nil {
@@ -1602,10 +2156,7 @@ func (r InstanceResource) createInstanceFromSourceInstance(ctx context.Context,
 
 	name := plan.Name.ValueString()
 
-	remote := plan.Remote.ValueString()
-	sourceInstanceProject := sourceInstanceModel.Project.ValueString()
-	target := plan.Target.ValueString()
-	sourceServer, err := r.provider.InstanceServer(remote, sourceInstanceProject, target)
+	sourceServer, err := r.sourceInstanceServer(plan, sourceInstanceModel)
 	if err != nil {
 		diags.Append(errors.NewInstanceServerError(err))
 		return diags
@@ -1622,62 +2173,7 @@ func (r InstanceResource) createInstanceFromSourceInstance(ctx context.Context,
 			AllowInconsistent: false,
 		}
 
-		sourceInstance, _, err := sourceServer.GetInstance(sourceInstanceName)
-		if err != nil {
-			diags.AddError(fmt.Sprintf("Failed to retrieve instance %q", sourceInstanceName), err.Error())
-			return diags
-		}
-
-		// Extract profiles, devices and config.
-		profiles, diags := ToProfileList(ctx, plan.Profiles)
-		diags.Append(diags...)
-
-		devices, diags := common.ToDeviceMap(ctx, plan.Devices)
-		diags.Append(diags...)
-
-		config, diags := common.ToConfigMap(ctx, plan.Config)
-		diags.Append(diags...)
-
-		if diags.HasError() {
-			return diags
-		}
-
-		sourceInstance.Profiles = profiles
-
-		// Allow setting additional config keys
-		for key, value := range config {
-			sourceInstance.Config[key] = value
-		}
-
-		// Allow setting device overrides
-		for k, m := range devices {
-			if sourceInstance.Devices[k] == nil {
-				sourceInstance.Devices[k] = m
-				continue
-			}
-
-			for key, value := range m {
-				sourceInstance.Devices[k][key] = value
-			}
-		}
-
-		for k := range sourceInstance.Config {
-			if !instanceIncludeWhenCopying(k, true) {
-				delete(sourceInstance.Config, k)
-			}
-		}
-
-		opCreate, err := destServer.CopyInstance(sourceServer, *sourceInstance, &args)
-		if err == nil {
-			err = opCreate.Wait()
-		}
-
-		if err != nil {
-			diags.AddError(fmt.Sprintf("Failed to create instance %q", name), err.Error())
-			return diags
-		}
-
-		return diags
+		return copyInstance(ctx, destServer, sourceServer, sourceInstanceName, plan, args)
 	}
 
 	args := incus.InstanceSnapshotCopyArgs{
@@ -1733,7 +2229,7 @@ func (r InstanceResource) createInstanceFromSourceInstance(ctx context.Context,
 
 	opCreate, err := destServer.CopyInstanceSnapshot(sourceServer, sourceInstanceName, *sourceSnapshot, &args)
 	if err == nil {
-		err = opCreate.Wait()
+		err = opCreate.WaitContext(ctx)
 	}
 
 	if err != nil {
@@ -1744,6 +2240,182 @@ func (r InstanceResource) createInstanceFromSourceInstance(ctx context.Context,
 	return diags
 }
 
+// copyInstance copies the source instance using the given arguments. The
+// profiles, config and devices of the plan are applied to the copy.
+func copyInstance(ctx context.Context, destServer incus.InstanceServer, sourceServer incus.InstanceServer, sourceInstanceName string, plan InstanceModel, args incus.InstanceCopyArgs) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	sourceInstance, _, err := sourceServer.GetInstance(sourceInstanceName)
+	if err != nil {
+		diags.AddError(fmt.Sprintf("Failed to retrieve instance %q", sourceInstanceName), err.Error())
+		return diags
+	}
+
+	// Extract profiles, devices and config.
+	profiles, profileDiags := ToProfileList(ctx, plan.Profiles)
+	diags.Append(profileDiags...)
+
+	devices, deviceDiags := common.ToDeviceMap(ctx, plan.Devices)
+	diags.Append(deviceDiags...)
+
+	config, configDiags := common.ToConfigMap(ctx, plan.Config)
+	diags.Append(configDiags...)
+
+	if diags.HasError() {
+		return diags
+	}
+
+	sourceInstance.Profiles = profiles
+
+	// Allow setting additional config keys
+	for key, value := range config {
+		sourceInstance.Config[key] = value
+	}
+
+	// Allow setting device overrides
+	for k, m := range devices {
+		if sourceInstance.Devices[k] == nil {
+			sourceInstance.Devices[k] = m
+			continue
+		}
+
+		for key, value := range m {
+			sourceInstance.Devices[k][key] = value
+		}
+	}
+
+	for k := range sourceInstance.Config {
+		if !instanceIncludeWhenCopying(k, true) {
+			delete(sourceInstance.Config, k)
+		}
+	}
+
+	opCreate, err := destServer.CopyInstance(sourceServer, *sourceInstance, &args)
+	if err == nil {
+		err = opCreate.WaitContext(ctx)
+	}
+
+	if err != nil {
+		diags.AddError(fmt.Sprintf("Failed to copy instance %q to %q", sourceInstanceName, args.Name), err.Error())
+		return diags
+	}
+
+	return diags
+}
+
+// sourceInstanceServer returns the server of the source instance. The source
+// instance is looked up on the remote of the instance, unless a different
+// remote is configured.
+func (r InstanceResource) sourceInstanceServer(plan InstanceModel, sourceInstanceModel SourceInstanceModel) (incus.InstanceServer, error) {
+	remote := plan.Remote.ValueString()
+	target := plan.Target.ValueString()
+
+	sourceRemote := sourceInstanceModel.Remote.ValueString()
+	if sourceRemote != "" && sourceRemote != remote {
+		// The target refers to a member of the destination cluster.
+		remote = sourceRemote
+		target = ""
+	}
+
+	return r.provider.InstanceServer(remote, sourceInstanceModel.Project.ValueString(), target)
+}
+
+// sourceInstanceRefreshRequired returns true if refresh is enabled for the
+// source instance and the refresh trigger has changed.
+func sourceInstanceRefreshRequired(ctx context.Context, plan InstanceModel, state InstanceModel) (bool, diag.Diagnostics) {
+	if plan.SourceInstance.IsNull() || plan.SourceInstance.IsUnknown() || state.SourceInstance.IsNull() {
+		return false, nil
+	}
+
+	var planSource, stateSource SourceInstanceModel
+
+	diags := plan.SourceInstance.As(ctx, &planSource, basetypes.ObjectAsOptions{})
+	diags.Append(state.SourceInstance.As(ctx, &stateSource, basetypes.ObjectAsOptions{})...)
+	if diags.HasError() {
+		return false, diags
+	}
+
+	return planSource.Refresh.ValueBool() && !planSource.RefreshTrigger.Equal(stateSource.RefreshTrigger), nil
+}
+
+// refreshInstanceFromSourceInstance incrementally syncs the instance from its
+// source instance. The instance is stopped, as running instances cannot be
+// refreshed.
+func (r InstanceResource) refreshInstanceFromSourceInstance(ctx context.Context, destServer incus.InstanceServer, plan InstanceModel) diag.Diagnostics {
+	var sourceInstanceModel SourceInstanceModel
+
+	diags := plan.SourceInstance.As(ctx, &sourceInstanceModel, basetypes.ObjectAsOptions{})
+	if diags.HasError() {
+		return diags
+	}
+
+	sourceServer, err := r.sourceInstanceServer(plan, sourceInstanceModel)
+	if err != nil {
+		diags.Append(errors.NewInstanceServerError(err))
+		return diags
+	}
+
+	name := plan.Name.ValueString()
+	_, diag := stopInstance(ctx, destServer, name, false, false)
+	if diag != nil {
+		diags.Append(diag)
+		return diags
+	}
+
+	args := incus.InstanceCopyArgs{
+		Name:         name,
+		InstanceOnly: true,
+		Refresh:      true,
+	}
+
+	return copyInstance(ctx, destServer, sourceServer, sourceInstanceModel.Name.ValueString(), plan, args)
+}
+
+// snapshotRestoreRequired returns true if the snapshot set in
+// "restore_snapshot" has to be restored, which is the case if either the
+// snapshot or the restore trigger has changed.
+func snapshotRestoreRequired(plan InstanceModel, state InstanceModel) bool {
+	if plan.RestoreSnapshot.IsNull() || plan.RestoreSnapshot.IsUnknown() {
+		return false
+	}
+
+	return !plan.RestoreSnapshot.Equal(state.RestoreSnapshot) || !plan.RestoreTrigger.Equal(state.RestoreTrigger)
+}
+
+// restoreInstanceSnapshot stops the instance and restores the snapshot with
+// the given name. The instance is left stopped, unless the snapshot is
+// stateful.
+func restoreInstanceSnapshot(ctx context.Context, server incus.InstanceServer, instanceName string, snapshotName string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	_, diag := stopInstance(ctx, server, instanceName, false, false)
+	if diag != nil {
+		diags.Append(diag)
+		return diags
+	}
+
+	instance, etag, err := server.GetInstance(instanceName)
+	if err != nil {
+		diags.AddError(fmt.Sprintf("Failed to retrieve instance %q", instanceName), err.Error())
+		return diags
+	}
+
+	req := instance.InstancePut
+	req.Restore = snapshotName
+
+	op, err := server.UpdateInstance(instanceName, req, etag)
+	if err == nil {
+		err = op.WaitContext(ctx)
+	}
+
+	if err != nil {
+		diags.AddError(fmt.Sprintf("Failed to restore snapshot %q of instance %q", snapshotName, instanceName), err.Error())
+		return diags
+	}
+
+	return nil
+}
+
 func instanceIncludeWhenCopying(configKey string, remoteCopy bool) bool {
 	if configKey == "volatile.base_image" {
 		return true // Include volatile.base_image always as it can help optimize copies.
@@ -1776,7 +2448,7 @@ func (r InstanceResource) createInstanceWithoutImage(ctx context.Context, server
 	// Initialize the instance. Instance will not be running after this call.
 	if err == nil {
 		// Wait for the instance to be created.
-		err = opCreate.Wait()
+		err = opCreate.WaitContext(ctx)
 	}
 
 	if err != nil {
@@ -1858,8 +2530,9 @@ func ToProfileListType(ctx context.Context, profiles []string) (types.List, diag
 }
 
 // startInstance starts an instance with the given name. It also waits
-// for it to become fully operational.
-func startInstance(ctx context.Context, server incus.InstanceServer, instanceName string) diag.Diagnostic {
+// for it to become fully operational. A frozen instance is unfrozen. If
+// stateful is true, the runtime state saved by a stateful stop is restored.
+func startInstance(ctx context.Context, server incus.InstanceServer, instanceName string, stateful bool) diag.Diagnostic {
 	st, etag, err := server.GetInstanceState(instanceName)
 	if err != nil {
 		return diag.NewErrorDiagnostic(fmt.Sprintf("Failed to retrieve state of instance %q", instanceName), err.Error())
@@ -1870,12 +2543,27 @@ func startInstance(ctx context.Context, server incus.InstanceServer, instanceNam
 		return nil
 	}
 
+	if isInstanceFrozen(*st) {
+		return unfreezeInstance(ctx, server, instanceName)
+	}
+
 	startReq := api.InstanceStatePut{
 		Action:  "start",
 		Force:   false,
 		Timeout: utils.ContextTimeout(ctx, 3*time.Minute),
 	}
 
+	// Restore the runtime state only if the instance has one, as a stateful
+	// start fails otherwise.
+	if stateful {
+		instance, _, err := server.GetInstance(instanceName)
+		if err != nil {
+			return diag.NewErrorDiagnostic(fmt.Sprintf("Failed to retrieve instance %q", instanceName), err.Error())
+		}
+
+		startReq.Stateful = instance.Stateful
+	}
+
 	// Start the instance.
 	op, err := server.UpdateInstanceState(instanceName, startReq, etag)
 	if err == nil {
@@ -1883,7 +2571,7 @@ func startInstance(ctx context.Context, server incus.InstanceServer, instanceNam
 	}
 
 	if err != nil {
-		return diag.NewErrorDiagnostic(fmt.Sprintf("Failed to start instance %q", instanceName), err.Error())
+		return withInstanceLogs(ctx, server, instanceName, diag.NewErrorDiagnostic(fmt.Sprintf("Failed to start instance %q", instanceName), err.Error()))
 	}
 
 	instanceStartedCheck := func() (any, string, error) {
@@ -1899,7 +2587,89 @@ func startInstance(ctx context.Context, server incus.InstanceServer, instanceNam
 	// the instance is fully started via a new API call.
 	_, err = waitForState(ctx, instanceStartedCheck, api.Running.String())
 	if err != nil {
-		return diag.NewErrorDiagnostic(fmt.Sprintf("Failed to wait for instance %q to start", instanceName), err.Error())
+		return withInstanceLogs(ctx, server, instanceName, diag.NewErrorDiagnostic(fmt.Sprintf("Failed to wait for instance %q to start", instanceName), err.Error()))
+	}
+
+	return nil
+}
+
+// migrateInstance moves the instance to the given cluster member or cluster
+// group. Running virtual machines with "migration.stateful" enabled are
+// migrated live. Other running instances are stopped, moved and started
+// again. Frozen instances cannot be migrated, so they are unfrozen first
+// and frozen again once moved.
+func migrateInstance(ctx context.Context, server incus.InstanceServer, instanceName string, target string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	instance, _, err := server.GetInstance(instanceName)
+	if err != nil {
+		diags.AddError(fmt.Sprintf("Failed to retrieve instance %q", instanceName), err.Error())
+		return diags
+	}
+
+	// Nothing to do if the instance is already located on the target.
+	if instance.Location == target {
+		return nil
+	}
+
+	group, isGroup := strings.CutPrefix(target, "@")
+	if isGroup {
+		member, _, err := server.GetClusterMember(instance.Location)
+		if err == nil && slices.Contains(member.Groups, group) {
+			return nil
+		}
+	}
+
+	frozen := instance.StatusCode == api.Frozen || instance.StatusCode == api.Freezing
+	if frozen {
+		diag := unfreezeInstance(ctx, server, instanceName)
+		if diag != nil {
+			diags.Append(diag)
+			return diags
+		}
+	}
+
+	running := frozen || instance.StatusCode == api.Running || instance.StatusCode == api.Ready
+	live := running && instance.Type == "virtual-machine" && incus_shared.IsTrue(instance.ExpandedConfig["migration.stateful"])
+
+	if running && !live {
+		_, diag := stopInstance(ctx, server, instanceName, false, false)
+		if diag != nil {
+			diags.Append(diag)
+			return diags
+		}
+	}
+
+	req := api.InstancePost{
+		Name:      instanceName,
+		Migration: true,
+		Live:      live,
+	}
+
+	op, err := server.UseTarget(target).MigrateInstance(instanceName, req)
+	if err == nil {
+		err = op.WaitContext(ctx)
+	}
+
+	if err != nil {
+		diags.AddError(fmt.Sprintf("Failed to migrate instance %q to %q", instanceName, target), err.Error())
+		return diags
+	}
+
+	if running && !live {
+		diag := startInstance(ctx, server, instanceName, false)
+		if diag != nil {
+			diags.Append(diag)
+			return diags
+		}
+	}
+
+	if frozen {
+		diag := freezeInstance(ctx, server, instanceName)
+		if diag != nil {
+			diags.Append(diag)
+			return diags
+		}
 	}
 
 	return nil
@@ -1908,8 +2678,9 @@ func startInstance(ctx context.Context, server incus.InstanceServer, instanceNam
 // stopInstance stops an instance with the given name. It waits for its
 // status to become Stopped or the instance to be removed (not found) in
 // case of an ephemeral instance. In the latter case, false is returned
-// along an error.
-func stopInstance(ctx context.Context, server incus.InstanceServer, instanceName string, force bool) (bool, diag.Diagnostic) {
+// along an error. If stateful is true, the runtime state of the instance
+// is saved so that it can be restored on the next start.
+func stopInstance(ctx context.Context, server incus.InstanceServer, instanceName string, force bool, stateful bool) (bool, diag.Diagnostic) {
 	st, etag, err := server.GetInstanceState(instanceName)
 	if err != nil {
 		return true, diag.NewErrorDiagnostic(fmt.Sprintf("Failed to retrieve state of instance %q", instanceName), err.Error())
@@ -1920,10 +2691,24 @@ func stopInstance(ctx context.Context, server incus.InstanceServer, instanceName
 		return true, nil
 	}
 
+	// A frozen instance cannot shut down gracefully or save its state.
+	if isInstanceFrozen(*st) {
+		d := unfreezeInstance(ctx, server, instanceName)
+		if d != nil {
+			return true, d
+		}
+
+		_, etag, err = server.GetInstanceState(instanceName)
+		if err != nil {
+			return true, diag.NewErrorDiagnostic(fmt.Sprintf("Failed to retrieve state of instance %q", instanceName), err.Error())
+		}
+	}
+
 	stopReq := api.InstanceStatePut{
-		Action:  "stop",
-		Force:   force,
-		Timeout: utils.ContextTimeout(ctx, 3*time.Minute),
+		Action:   "stop",
+		Force:    force,
+		Stateful: stateful,
+		Timeout:  utils.ContextTimeout(ctx, 3*time.Minute),
 	}
 
 	// Stop the instance.
@@ -1956,34 +2741,104 @@ func stopInstance(ctx context.Context, server incus.InstanceServer, instanceName
 	return true, nil
 }
 
+// freezeInstance freezes a running instance with the given name, pausing all
+// of its processes while keeping its memory. It waits for its status to
+// become Frozen.
+func freezeInstance(ctx context.Context, server incus.InstanceServer, instanceName string) diag.Diagnostic {
+	return changeInstanceFreezeState(ctx, server, instanceName, "freeze", api.Frozen)
+}
+
+// unfreezeInstance resumes a frozen instance with the given name. It waits
+// for its status to become Running.
+func unfreezeInstance(ctx context.Context, server incus.InstanceServer, instanceName string) diag.Diagnostic {
+	return changeInstanceFreezeState(ctx, server, instanceName, "unfreeze", api.Running)
+}
+
+// changeInstanceFreezeState applies the given freeze or unfreeze action to
+// the instance and waits for it to reach the given status.
+func changeInstanceFreezeState(ctx context.Context, server incus.InstanceServer, instanceName string, action string, status api.StatusCode) diag.Diagnostic {
+	st, etag, err := server.GetInstanceState(instanceName)
+	if err != nil {
+		return diag.NewErrorDiagnostic(fmt.Sprintf("Failed to retrieve state of instance %q", instanceName), err.Error())
+	}
+
+	// Return if the instance already has the expected status.
+	if st.StatusCode == status {
+		return nil
+	}
+
+	req := api.InstanceStatePut{
+		Action:  action,
+		Timeout: utils.ContextTimeout(ctx, 3*time.Minute),
+	}
+
+	op, err := server.UpdateInstanceState(instanceName, req, etag)
+	if err == nil {
+		err = op.WaitContext(ctx)
+	}
+
+	if err != nil {
+		return diag.NewErrorDiagnostic(fmt.Sprintf("Failed to %s instance %q", action, instanceName), err.Error())
+	}
+
+	instanceStatusCheck := func() (any, string, error) {
+		st, _, err := server.GetInstanceState(instanceName)
+		if err != nil {
+			return st, "Error", err
+		}
+
+		return st, st.Status, nil
+	}
+
+	_, err = waitForState(ctx, instanceStatusCheck, status.String())
+	if err != nil {
+		return diag.NewErrorDiagnostic(fmt.Sprintf("Failed to wait for instance %q to %s", instanceName, action), err.Error())
+	}
+
+	return nil
+}
+
 // waitFor waits for the instance with the given name to reach the desired
 // state. It returns an error if the instance does not reach the desired
 // state within the given timeout.
 func waitFor(ctx context.Context, server incus.InstanceServer, instanceName string, waitFor types.Set, isVirtualMachine bool) diag.Diagnostics {
-	var diags diag.Diagnostics
+	if waitFor.IsNull() || waitFor.IsUnknown() {
+		return nil
+	}
 
-	waitForMap, diags := ToWaitForConfigMap(ctx, waitFor)
+	// Multiple conditions of the same type are allowed, so the entries are
+	// processed as a list.
+	waitForList := make([]WaitForModel, 0, len(waitFor.Elements()))
+	diags := waitFor.ElementsAs(ctx, &waitForList, false)
 	if diags.HasError() {
 		return diags
 	}
 
-	for waitForModelType, waitForModel := range waitForMap {
+	for _, waitForModel := range waitForList {
+		waitForModelType := waitForModel.Type.ValueString()
+
 		switch waitForModelType {
 		case "agent":
-			diags = waitForInstanceAgent(ctx, server, instanceName)
+			diags.Append(waitForInstanceAgent(ctx, server, instanceName)...)
 		case "cloud-init":
-			diags = waitForInstanceCloudInit(ctx, server, instanceName, isVirtualMachine)
+			diags.Append(waitForInstanceCloudInit(ctx, server, instanceName, isVirtualMachine)...)
 		case "delay":
 			duration := waitForModel.Delay.ValueString()
-			diags = waitForInstanceWithDelay(ctx, server, instanceName, duration)
+			diags.Append(waitForInstanceWithDelay(ctx, server, instanceName, duration)...)
+		case "exec", "file", "port":
+			diags.Append(waitForInstanceCondition(ctx, server, instanceName, waitForModel)...)
 		case "ipv4", "ipv6":
 			nic := waitForModel.Nic.ValueString()
-			diags = waitForInstanceNetwork(ctx, server, instanceName, waitForModelType, nic)
+			diags.Append(waitForInstanceNetwork(ctx, server, instanceName, waitForModelType, nic)...)
 		case "ready":
-			diags = waitForInstanceToBeReady(ctx, server, instanceName)
+			diags.Append(waitForInstanceToBeReady(ctx, server, instanceName)...)
 		default:
 			diags.AddError(fmt.Sprintf("Invalid value for wait_for: %q", waitForModelType), "")
 		}
+
+		if diags.HasError() {
+			return withInstanceLogsAll(ctx, server, instanceName, diags)
+		}
 	}
 
 	return diags
@@ -2108,6 +2963,156 @@ func waitForInstanceToBeReady(ctx context.Context, server incus.InstanceServer,
 	return nil
 }
 
+// waitForInstanceCondition waits for an exec, port or file condition to be
+// met inside the instance. The condition is checked at the configured
+// interval until it is met or the configured timeout elapses.
+func waitForInstanceCondition(ctx context.Context, server incus.InstanceServer, instanceName string, waitForModel WaitForModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var timeout, interval time.Duration
+	var err error
+
+	if waitForModel.Timeout.ValueString() != "" {
+		timeout, err = time.ParseDuration(waitForModel.Timeout.ValueString())
+		if err != nil {
+			diags.AddError("Invalid wait_for timeout", err.Error())
+			return diags
+		}
+	}
+
+	if waitForModel.Interval.ValueString() != "" {
+		interval, err = time.ParseDuration(waitForModel.Interval.ValueString())
+		if err != nil {
+			diags.AddError("Invalid wait_for interval", err.Error())
+			return diags
+		}
+	}
+
+	ctx, cancel := utils.ContextWithTimeout(ctx, timeout)
+	defer cancel()
+
+	var summary string
+	var check func() error
+
+	switch {
+	case waitForModel.IsExec():
+		var command []string
+		diags = waitForModel.Command.ElementsAs(ctx, &command, false)
+		if diags.HasError() {
+			return diags
+		}
+
+		summary = fmt.Sprintf("Failed to wait for command %q to succeed in instance %q", strings.Join(command, " "), instanceName)
+		check = func() error {
+			result, err := common.RunInstanceExec(ctx, server, instanceName, common.InstanceExecConfig{Command: command})
+			if err != nil {
+				return fmt.Errorf("%s", formatExecError(err, result.Stdout, result.Stderr))
+			}
+
+			return nil
+		}
+
+	case waitForModel.IsPort():
+		port := waitForModel.Port.ValueInt64()
+		summary = fmt.Sprintf("Failed to wait for port %d to listen in instance %q", port, instanceName)
+		check = func() error {
+			// The TCP sockets are read from procfs, so no tools are
+			// required inside the instance. The IPv6 table may not
+			// exist, so a failure to read it is allowed.
+			execConfig := common.InstanceExecConfig{
+				Command:          []string{"cat", "/proc/net/tcp", "/proc/net/tcp6"},
+				AllowedExitCodes: []int64{1},
+			}
+
+			result, err := common.RunInstanceExec(ctx, server, instanceName, execConfig)
+			if err != nil {
+				return err
+			}
+
+			if !isPortListening(result.Stdout, port) {
+				return fmt.Errorf("Port %d is not listening", port)
+			}
+
+			return nil
+		}
+
+	case waitForModel.IsFile():
+		filePath := waitForModel.Path.ValueString()
+		summary = fmt.Sprintf("Failed to wait for file %q in instance %q", filePath, instanceName)
+		check = func() error {
+			content, _, err := server.GetInstanceFile(instanceName, filePath)
+			if err != nil {
+				return err
+			}
+
+			if content != nil {
+				_ = content.Close()
+			}
+
+			return nil
+		}
+
+	default:
+		diags.AddError(fmt.Sprintf("Invalid value for wait_for: %q", waitForModel.Type.ValueString()), "")
+		return diags
+	}
+
+	// Failed checks are retried, so only the last failure is reported.
+	var lastErr error
+	conditionCheck := func() (any, string, error) {
+		lastErr = check()
+		if lastErr != nil {
+			return nil, "Waiting for condition", nil
+		}
+
+		return struct{}{}, "OK", nil
+	}
+
+	stateRefreshConf := &retry.StateChangeConf{
+		Refresh:      conditionCheck,
+		Target:       []string{"OK"},
+		Timeout:      time.Duration(utils.ContextTimeout(ctx, 3*time.Minute)) * time.Second,
+		MinTimeout:   2 * time.Second,
+		PollInterval: interval,
+	}
+
+	_, err = stateRefreshConf.WaitForStateContext(ctx)
+	if err != nil {
+		if lastErr != nil {
+			err = fmt.Errorf("%v\nLast error: %v", err, lastErr)
+		}
+
+		diags.AddError(summary, err.Error())
+		return diags
+	}
+
+	return nil
+}
+
+// isPortListening returns true if the given contents of /proc/net/tcp or
+// /proc/net/tcp6 contain a listening socket on the given port.
+func isPortListening(procNetTCP string, port int64) bool {
+	for _, line := range strings.Split(procNetTCP, "\n") {
+		// Fields: sl local_address rem_address st ...
+		fields := strings.Fields(line)
+		if len(fields) < 4 || fields[3] != "0A" {
+			continue
+		}
+
+		_, hexPort, found := strings.Cut(fields[1], ":")
+		if !found {
+			continue
+		}
+
+		localPort, err := strconv.ParseInt(hexPort, 16, 64)
+		if err == nil && localPort == port {
+			return true
+		}
+	}
+
+	return false
+}
+
 type cloudInitStatus struct {
 	Status string   `json:"status"`
 	Errors []string `json:"errors"`
@@ -2126,18 +3131,18 @@ func waitForInstanceCloudInit(ctx context.Context, server incus.InstanceServer,
 		Command: []string{"cloud-init", "status", "--wait", "--format", "json"},
 	}
 
-	stdout, stderr, err := common.RunInstanceExec(ctx, server, instanceName, execConfig)
+	result, err := common.RunInstanceExec(ctx, server, instanceName, execConfig)
 	if err != nil {
 		var diags diag.Diagnostics
 		diags.AddError(
 			fmt.Sprintf("Failed to wait for cloud-init in instance %q", instanceName),
-			formatExecError(err, stdout, stderr),
+			formatExecError(err, result.Stdout, result.Stderr),
 		)
 		return diags
 	}
 
 	var status cloudInitStatus
-	if err := json.Unmarshal([]byte(stdout), &status); err != nil {
+	if err := json.Unmarshal([]byte(result.Stdout), &status); err != nil {
 		return nil
 	}
 
@@ -2161,7 +3166,7 @@ func waitForState(ctx context.Context, refreshFunc retry.StateRefreshFunc, targe
 	stateRefreshConf := &retry.StateChangeConf{
 		Refresh:    refreshFunc,
 		Target:     targets,
-		Timeout:    3 * time.Minute,
+		Timeout:    time.Duration(utils.ContextTimeout(ctx, 3*time.Minute)) * time.Second,
 		MinTimeout: 2 * time.Second, // Timeout increases: 2, 4, 8, 10, 10, ...
 		Delay:      2 * time.Second, // Delay before the first check/refresh.
 	}
@@ -2169,6 +3174,122 @@ func waitForState(ctx context.Context, refreshFunc retry.StateRefreshFunc, targe
 	return stateRefreshConf.WaitForStateContext(ctx)
 }
 
+// instanceLogLines is the number of log lines that are included in the
+// diagnostics of a failed start or wait.
+const instanceLogLines = 50
+
+// ansiEscapeRegexp matches terminal escape sequences in the console log.
+var ansiEscapeRegexp = regexp.MustCompile(`\x1b\[[0-9;?]*[A-Za-z]`)
+
+// withInstanceLogs appends the logs of the instance to the detail of the
+// given error diagnostic.
+func withInstanceLogs(ctx context.Context, server incus.InstanceServer, instanceName string, d diag.Diagnostic) diag.Diagnostic {
+	logs := instanceLogs(ctx, server, instanceName)
+	if logs == "" {
+		return d
+	}
+
+	return diag.NewErrorDiagnostic(d.Summary(), strings.TrimSpace(d.Detail()+"\n\n"+logs))
+}
+
+// withInstanceLogsAll appends the logs of the instance to the detail of all
+// error diagnostics. The logs are only retrieved once.
+func withInstanceLogsAll(ctx context.Context, server incus.InstanceServer, instanceName string, diags diag.Diagnostics) diag.Diagnostics {
+	logs := instanceLogs(ctx, server, instanceName)
+	if logs == "" {
+		return diags
+	}
+
+	result := make(diag.Diagnostics, 0, len(diags))
+	for _, d := range diags {
+		if d.Severity() == diag.SeverityError {
+			d = diag.NewErrorDiagnostic(d.Summary(), strings.TrimSpace(d.Detail()+"\n\n"+logs))
+		}
+
+		result = append(result, d)
+	}
+
+	return result
+}
+
+// instanceLogs returns the tail of the console log of the instance and, if
+// commands can be run in the instance, the cloud-init status and the tail of
+// the cloud-init output log. Logs that cannot be retrieved are skipped.
+func instanceLogs(ctx context.Context, server incus.InstanceServer, instanceName string) string {
+	// The logs are also needed if the wait failed because the context
+	// deadline was exceeded.
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 30*time.Second)
+	defer cancel()
+
+	var sections []string
+
+	console, err := server.GetInstanceConsoleLog(instanceName, &incus.InstanceConsoleLogArgs{})
+	if err == nil {
+		content, err := io.ReadAll(console)
+		_ = console.Close()
+		if err == nil {
+			sections = appendLogSection(sections, "Console log", string(content))
+		}
+	}
+
+	state, _, err := server.GetInstanceState(instanceName)
+	if err != nil || !isInstanceOperational(*state) {
+		return strings.Join(sections, "\n\n")
+	}
+
+	commands := []struct {
+		title   string
+		command []string
+	}{
+		{"cloud-init status", []string{"cloud-init", "status", "--long"}},
+		{"/var/log/cloud-init-output.log", []string{"tail", "-n", strconv.Itoa(instanceLogLines), "/var/log/cloud-init-output.log"}},
+	}
+
+	for _, c := range commands {
+		// cloud-init reports errors with the exit codes 1 and 2.
+		execConfig := common.InstanceExecConfig{
+			Command:          c.command,
+			AllowedExitCodes: []int64{1, 2},
+			Timeout:          10 * time.Second,
+			HasTimeout:       true,
+		}
+
+		result, err := common.RunInstanceExec(ctx, server, instanceName, execConfig)
+		if err != nil {
+			continue
+		}
+
+		sections = appendLogSection(sections, c.title, result.Stdout)
+	}
+
+	return strings.Join(sections, "\n\n")
+}
+
+// appendLogSection appends the last lines of the given log as a titled
+// section. The title only mentions the line count if the log was
+// truncated. Empty logs are skipped.
+func appendLogSection(sections []string, title string, log string) []string {
+	log = ansiEscapeRegexp.ReplaceAllString(log, "")
+	log = strings.ReplaceAll(log, "\r", "")
+
+	lines := strings.Split(strings.TrimRight(log, "\n"), "\n")
+	truncated := len(lines) > instanceLogLines
+	if truncated {
+		lines = lines[len(lines)-instanceLogLines:]
+	}
+
+	content := strings.Join(lines, "\n")
+	if strings.TrimSpace(content) == "" {
+		return sections
+	}
+
+	if truncated {
+		title = fmt.Sprintf("%s (last %d lines)", title, len(lines))
+	}
+
+	return append(sections, fmt.Sprintf("%s:\n%s", title, content))
+}
+
 // isInstanceOperational determines if an instance is fully operational based
 // on its state. It returns true if the instance is running and the reported
 // process count is positive. Checking for a positive process count is essential
@@ -2188,6 +3309,26 @@ func isInstanceReady(s api.InstanceState) bool {
 	return s.StatusCode == api.Ready
 }
 
+// isInstanceFrozen returns true if its status is either "Frozen" or
+// "Freezing".
+func isInstanceFrozen(s api.InstanceState) bool {
+	return s.StatusCode == api.Frozen || s.StatusCode == api.Freezing
+}
+
+// instancePowerState maps the status of an instance to one of the values
+// supported by the "desired_state" attribute.
+func instancePowerState(s api.InstanceState) string {
+	if isInstanceRunning(s) {
+		return instanceStateRunning
+	}
+
+	if isInstanceFrozen(s) {
+		return instanceStateFrozen
+	}
+
+	return instanceStateStopped
+}
+
 // isInstanceStopped returns true if instance's status "Stopped".
 func isInstanceStopped(s api.InstanceState) bool {
 	return s.StatusCode == api.Stopped
@@ -0,0 +1,397 @@
+package instance
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	incus "github.com/lxc/incus/v7/client"
+	"github.com/lxc/incus/v7/shared/api"
+
+	"github.com/lxc/terraform-provider-incus/internal/common"
+	"github.com/lxc/terraform-provider-incus/internal/errors"
+	provider_config "github.com/lxc/terraform-provider-incus/internal/provider-config"
+	"github.com/lxc/terraform-provider-incus/internal/utils"
+)
+
+type InstanceBackupModel struct {
+	Name                 types.String   `tfsdk:"name"`
+	Instance             types.String   `tfsdk:"instance"`
+	InstanceOnly         types.Bool     `tfsdk:"instance_only"`
+	OptimizedStorage     types.Bool     `tfsdk:"optimized_storage"`
+	CompressionAlgorithm types.String   `tfsdk:"compression_algorithm"`
+	ExpiresAt            types.String   `tfsdk:"expires_at"`
+	OutputPath           types.String   `tfsdk:"output_path"`
+	Project              types.String   `tfsdk:"project"`
+	Remote               types.String   `tfsdk:"remote"`
+	Timeouts             timeouts.Value `tfsdk:"timeouts"`
+
+	// Computed.
+	CreatedAt types.Int64  `tfsdk:"created_at"`
+	SHA256    types.String `tfsdk:"sha256"`
+}
+
+// InstanceBackupResource represent Incus instance backup resource.
+type InstanceBackupResource struct {
+	provider *provider_config.IncusProviderConfig
+}
+
+// NewInstanceBackupResource returns a new instance backup resource.
+func NewInstanceBackupResource() resource.Resource {
+	return &InstanceBackupResource{}
+}
+
+func (r InstanceBackupResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_instance_backup", req.ProviderTypeName)
+}
+
+func (r InstanceBackupResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+
+			"instance": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+
+			"instance_only": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+
+			"optimized_storage": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+
+			"compression_algorithm": schema.StringAttribute{
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+
+			"expires_at": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplaceIfConfigured(),
+				},
+				Validators: []validator.String{
+					common.TimestampValidator{},
+				},
+			},
+
+			"output_path": schema.StringAttribute{
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+
+			"project": schema.StringAttribute{
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+
+			"remote": schema.StringAttribute{
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+
+			// Computed.
+
+			"created_at": schema.Int64Attribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"sha256": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func (r *InstanceBackupResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	data := req.ProviderData
+	if data == nil {
+		return
+	}
+
+	provider, ok := data.(*provider_config.IncusProviderConfig)
+	if !ok {
+		resp.Diagnostics.Append(errors.NewProviderDataTypeError(req.ProviderData))
+		return
+	}
+
+	r.provider = provider
+}
+
+func (r InstanceBackupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan InstanceBackupModel
+
+	// Fetch resource model from Terraform plan.
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, 0)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := utils.ContextWithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	remote := plan.Remote.ValueString()
+	project := plan.Project.ValueString()
+	server, err := r.provider.InstanceServer(remote, project, "")
+	if err != nil {
+		resp.Diagnostics.Append(errors.NewInstanceServerError(err))
+		return
+	}
+
+	instanceName := plan.Instance.ValueString()
+	backupName := plan.Name.ValueString()
+
+	expiresAt, err := common.ToTimestamp(plan.ExpiresAt)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("expires_at"), "Invalid timestamp", err.Error())
+		return
+	}
+
+	backupReq := api.InstanceBackupsPost{
+		Name:                 backupName,
+		InstanceOnly:         plan.InstanceOnly.ValueBool(),
+		OptimizedStorage:     plan.OptimizedStorage.ValueBool(),
+		CompressionAlgorithm: plan.CompressionAlgorithm.ValueString(),
+	}
+
+	if expiresAt != nil {
+		backupReq.ExpiresAt = *expiresAt
+	}
+
+	op, err := server.CreateInstanceBackup(instanceName, backupReq)
+	if err == nil {
+		err = op.WaitContext(ctx)
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to create backup %q for instance %q", backupName, instanceName), err.Error())
+		return
+	}
+
+	plan.SHA256 = types.StringNull()
+
+	// Download the backup, if requested.
+	outputPath := plan.OutputPath.ValueString()
+	if outputPath != "" {
+		checksum, err := common.DownloadBackup(outputPath, func(req *incus.BackupFileRequest) error {
+			_, err := server.GetInstanceBackupFile(instanceName, backupName, req)
+			return err
+		})
+		if err != nil {
+			resp.Diagnostics.AddError(fmt.Sprintf("Failed to download backup %q of instance %q to %q", backupName, instanceName, outputPath), err.Error())
+
+			// Remove the backup, as it is not tracked in the state.
+			op, err := server.DeleteInstanceBackup(instanceName, backupName)
+			if err == nil {
+				_ = op.WaitContext(ctx)
+			}
+
+			return
+		}
+
+		plan.SHA256 = types.StringValue(checksum)
+	}
+
+	// Update Terraform state.
+	diags = r.SyncState(ctx, &resp.State, server, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r InstanceBackupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state InstanceBackupModel
+
+	// Fetch resource model from Terraform state.
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	remote := state.Remote.ValueString()
+	project := state.Project.ValueString()
+	server, err := r.provider.InstanceServer(remote, project, "")
+	if err != nil {
+		resp.Diagnostics.Append(errors.NewInstanceServerError(err))
+		return
+	}
+
+	// Update Terraform state.
+	diags = r.SyncState(ctx, &resp.State, server, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r InstanceBackupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan InstanceBackupModel
+
+	// Fetch resource model from Terraform plan.
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// All backup attributes require replacement, therefore only
+	// the timeouts can change in place.
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r InstanceBackupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state InstanceBackupModel
+
+	// Fetch resource model from Terraform state.
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, 0)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := utils.ContextWithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	remote := state.Remote.ValueString()
+	project := state.Project.ValueString()
+	server, err := r.provider.InstanceServer(remote, project, "")
+	if err != nil {
+		resp.Diagnostics.Append(errors.NewInstanceServerError(err))
+		return
+	}
+
+	instanceName := state.Instance.ValueString()
+	backupName := state.Name.ValueString()
+
+	op, err := server.DeleteInstanceBackup(instanceName, backupName)
+	if err == nil {
+		err = op.WaitContext(ctx)
+	}
+
+	if err != nil && !errors.IsNotFoundError(err) {
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to remove backup %q for instance %q", backupName, instanceName), err.Error())
+	}
+}
+
+func (r InstanceBackupResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	meta := common.ImportMetadata{
+		ResourceName:   "instance_backup",
+		RequiredFields: []string{"instance", "name"},
+	}
+
+	fields, diag := meta.ParseImportID(req.ID)
+	if diag != nil {
+		resp.Diagnostics.Append(diag)
+		return
+	}
+
+	for k, v := range fields {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(k), v)...)
+	}
+}
+
+// SyncState fetches the server's current state for an instance backup and
+// updates the provided model. It then applies this updated model as the new
+// state in Terraform.
+func (r InstanceBackupResource) SyncState(ctx context.Context, tfState *tfsdk.State, server incus.InstanceServer, m InstanceBackupModel) diag.Diagnostics {
+	instanceName := m.Instance.ValueString()
+	backupName := m.Name.ValueString()
+
+	backup, _, err := server.GetInstanceBackup(instanceName, backupName)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			tfState.RemoveResource(ctx)
+			return nil
+		}
+
+		return diag.Diagnostics{diag.NewErrorDiagnostic(
+			fmt.Sprintf("Failed to retrieve backup %q for instance %q", backupName, instanceName),
+			err.Error(),
+		)}
+	}
+
+	m.InstanceOnly = types.BoolValue(backup.InstanceOnly)
+	m.OptimizedStorage = types.BoolValue(backup.OptimizedStorage)
+	m.ExpiresAt = common.ToTimestampType(&backup.ExpiresAt, m.ExpiresAt)
+	m.CreatedAt = types.Int64Value(backup.CreatedAt.Unix())
+
+	return tfState.Set(ctx, &m)
+}
@@ -0,0 +1,108 @@
+package instance_test
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	petname "github.com/dustinkirkland/golang-petname"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/lxc/terraform-provider-incus/internal/acctest"
+)
+
+func TestAccInstanceBackup_basic(t *testing.T) {
+	instanceName := petname.Generate(2, "-")
+	backupName := petname.Generate(2, "-")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccInstanceBackup_basic(instanceName, backupName, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_instance_backup.backup1", "name", backupName),
+					resource.TestCheckResourceAttr("incus_instance_backup.backup1", "instance", instanceName),
+					resource.TestCheckResourceAttr("incus_instance_backup.backup1", "instance_only", "true"),
+					resource.TestCheckResourceAttr("incus_instance_backup.backup1", "optimized_storage", "false"),
+					resource.TestCheckResourceAttr("incus_instance_backup.backup1", "expires_at", "2030-01-01T00:00:00Z"),
+					resource.TestCheckResourceAttrSet("incus_instance_backup.backup1", "created_at"),
+					resource.TestCheckNoResourceAttr("incus_instance_backup.backup1", "sha256"),
+				),
+			},
+			{
+				ResourceName:                         "incus_instance_backup.backup1",
+				ImportStateId:                        fmt.Sprintf("/%s/%s", instanceName, backupName),
+				ImportStateVerifyIdentifierAttribute: "name",
+				ImportStateVerifyIgnore:              []string{"compression_algorithm"},
+				ImportStateVerify:                    true,
+				ImportState:                          true,
+			},
+		},
+	})
+}
+
+func TestAccInstanceBackup_outputPath(t *testing.T) {
+	instanceName := petname.Generate(2, "-")
+	backupName := petname.Generate(2, "-")
+	restoredName := petname.Generate(2, "-")
+	outputPath := filepath.Join(t.TempDir(), "backup.tar.gz")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccInstanceBackup_basic(instanceName, backupName, outputPath),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_instance_backup.backup1", "name", backupName),
+					resource.TestCheckResourceAttr("incus_instance_backup.backup1", "output_path", outputPath),
+					resource.TestMatchResourceAttr("incus_instance_backup.backup1", "sha256", regexp.MustCompile(`^[0-9a-f]{64}$`)),
+				),
+			},
+			{
+				// Restore the downloaded backup into a new instance.
+				Config: testAccInstanceBackup_basic(instanceName, backupName, outputPath) + testAccInstanceBackup_restore(restoredName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_instance.restored", "name", restoredName),
+					resource.TestCheckResourceAttr("incus_instance.restored", "status", "Running"),
+				),
+			},
+		},
+	})
+}
+
+func testAccInstanceBackup_basic(instanceName string, backupName string, outputPath string) string {
+	output := ""
+	if outputPath != "" {
+		output = fmt.Sprintf("output_path = %q", outputPath)
+	}
+
+	return fmt.Sprintf(`
+resource "incus_instance" "instance1" {
+  name    = "%s"
+  image   = "%s"
+  running = false
+}
+
+resource "incus_instance_backup" "backup1" {
+  name                  = "%s"
+  instance              = incus_instance.instance1.name
+  instance_only         = true
+  compression_algorithm = "gzip"
+  expires_at            = "2030-01-01T00:00:00Z"
+  %s
+}
+	`, instanceName, acctest.TestImage, backupName, output)
+}
+
+func testAccInstanceBackup_restore(name string) string {
+	return fmt.Sprintf(`
+resource "incus_instance" "restored" {
+  name        = "%s"
+  source_file = incus_instance_backup.backup1.output_path
+}
+	`, name)
+}
@@ -0,0 +1,555 @@
+package instance
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	incus "github.com/lxc/incus/v7/client"
+
+	"github.com/lxc/terraform-provider-incus/internal/common"
+	"github.com/lxc/terraform-provider-incus/internal/errors"
+	provider_config "github.com/lxc/terraform-provider-incus/internal/provider-config"
+	"github.com/lxc/terraform-provider-incus/internal/utils"
+)
+
+type InstanceExecModel struct {
+	Instance         types.String   `tfsdk:"instance"`
+	Command          types.List     `tfsdk:"command"`
+	Environment      types.Map      `tfsdk:"environment"`
+	WorkingDir       types.String   `tfsdk:"working_dir"`
+	UserID           types.Int64    `tfsdk:"uid"`
+	GroupID          types.Int64    `tfsdk:"gid"`
+	Timeout          types.String   `tfsdk:"timeout"`
+	AllowedExitCodes types.List     `tfsdk:"allowed_exit_codes"`
+	Triggers         types.Map      `tfsdk:"triggers"`
+	OnDestroy        types.Object   `tfsdk:"on_destroy"`
+	Project          types.String   `tfsdk:"project"`
+	Remote           types.String   `tfsdk:"remote"`
+	Timeouts         timeouts.Value `tfsdk:"timeouts"`
+
+	// Computed.
+	Stdout   types.String `tfsdk:"stdout"`
+	Stderr   types.String `tfsdk:"stderr"`
+	ExitCode types.Int64  `tfsdk:"exit_code"`
+}
+
+type InstanceExecOnDestroyModel struct {
+	Command          types.List   `tfsdk:"command"`
+	Environment      types.Map    `tfsdk:"environment"`
+	WorkingDir       types.String `tfsdk:"working_dir"`
+	UserID           types.Int64  `tfsdk:"uid"`
+	GroupID          types.Int64  `tfsdk:"gid"`
+	Timeout          types.String `tfsdk:"timeout"`
+	AllowedExitCodes types.List   `tfsdk:"allowed_exit_codes"`
+}
+
+// InstanceExecResource represent Incus instance exec resource.
+type InstanceExecResource struct {
+	provider *provider_config.IncusProviderConfig
+}
+
+// NewInstanceExecResource returns a new instance exec resource.
+func NewInstanceExecResource() resource.Resource {
+	return &InstanceExecResource{}
+}
+
+func (r InstanceExecResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_instance_exec", req.ProviderTypeName)
+}
+
+func (r InstanceExecResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"instance": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+
+			"command": schema.ListAttribute{
+				Required:    true,
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+			},
+
+			"environment": schema.MapAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+
+			"working_dir": schema.StringAttribute{
+				Optional: true,
+			},
+
+			"uid": schema.Int64Attribute{
+				Optional: true,
+			},
+
+			"gid": schema.Int64Attribute{
+				Optional: true,
+			},
+
+			"timeout": schema.StringAttribute{
+				Optional: true,
+			},
+
+			"allowed_exit_codes": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.Int64Type,
+			},
+
+			"triggers": schema.MapAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+
+			"on_destroy": schema.SingleNestedAttribute{
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"command": schema.ListAttribute{
+						Required:    true,
+						ElementType: types.StringType,
+						Validators: []validator.List{
+							listvalidator.SizeAtLeast(1),
+						},
+					},
+					"environment": schema.MapAttribute{
+						Optional:    true,
+						ElementType: types.StringType,
+					},
+					"working_dir": schema.StringAttribute{
+						Optional: true,
+					},
+					"uid": schema.Int64Attribute{
+						Optional: true,
+					},
+					"gid": schema.Int64Attribute{
+						Optional: true,
+					},
+					"timeout": schema.StringAttribute{
+						Optional: true,
+					},
+					"allowed_exit_codes": schema.ListAttribute{
+						Optional:    true,
+						ElementType: types.Int64Type,
+					},
+				},
+			},
+
+			"project": schema.StringAttribute{
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+
+			"remote": schema.StringAttribute{
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+
+			// Computed.
+
+			"stdout": schema.StringAttribute{
+				Computed: true,
+			},
+
+			"stderr": schema.StringAttribute{
+				Computed: true,
+			},
+
+			"exit_code": schema.Int64Attribute{
+				Computed: true,
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func (r *InstanceExecResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	data := req.ProviderData
+	if data == nil {
+		return
+	}
+
+	provider, ok := data.(*provider_config.IncusProviderConfig)
+	if !ok {
+		resp.Diagnostics.Append(errors.NewProviderDataTypeError(req.ProviderData))
+		return
+	}
+
+	r.provider = provider
+}
+
+// ModifyPlan keeps the results of the previous run, unless the command is
+// going to run again.
+func (r InstanceExecResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do on create or destroy.
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan InstanceExecModel
+	var state InstanceExecModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rerun, diags := shouldRerunInstanceExec(ctx, plan, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if rerun {
+		return
+	}
+
+	resp.Plan.SetAttribute(ctx, path.Root("stdout"), state.Stdout)
+	resp.Plan.SetAttribute(ctx, path.Root("stderr"), state.Stderr)
+	resp.Plan.SetAttribute(ctx, path.Root("exit_code"), state.ExitCode)
+}
+
+func (r InstanceExecResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan InstanceExecModel
+
+	// Fetch resource model from Terraform plan.
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, 0)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := utils.ContextWithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	remote := plan.Remote.ValueString()
+	project := plan.Project.ValueString()
+	server, err := r.provider.InstanceServer(remote, project, "")
+	if err != nil {
+		resp.Diagnostics.Append(errors.NewInstanceServerError(err))
+		return
+	}
+
+	diags = r.run(ctx, server, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Update Terraform state.
+	diags = r.SyncState(ctx, &resp.State, server, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r InstanceExecResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state InstanceExecModel
+
+	// Fetch resource model from Terraform state.
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	remote := state.Remote.ValueString()
+	project := state.Project.ValueString()
+	server, err := r.provider.InstanceServer(remote, project, "")
+	if err != nil {
+		resp.Diagnostics.Append(errors.NewInstanceServerError(err))
+		return
+	}
+
+	// Update Terraform state.
+	diags = r.SyncState(ctx, &resp.State, server, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update runs the command again if the command, its settings or the
+// triggers have changed.
+func (r InstanceExecResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan InstanceExecModel
+	var state InstanceExecModel
+
+	// Fetch resource model from Terraform plan.
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, 0)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := utils.ContextWithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	remote := plan.Remote.ValueString()
+	project := plan.Project.ValueString()
+	server, err := r.provider.InstanceServer(remote, project, "")
+	if err != nil {
+		resp.Diagnostics.Append(errors.NewInstanceServerError(err))
+		return
+	}
+
+	rerun, diags := shouldRerunInstanceExec(ctx, plan, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if rerun {
+		diags = r.run(ctx, server, &plan)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	} else {
+		plan.Stdout = state.Stdout
+		plan.Stderr = state.Stderr
+		plan.ExitCode = state.ExitCode
+	}
+
+	// Update Terraform state.
+	diags = r.SyncState(ctx, &resp.State, server, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete runs the on_destroy command, if configured. The command is skipped
+// if the instance no longer exists or is not running.
+func (r InstanceExecResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state InstanceExecModel
+
+	// Fetch resource model from Terraform state.
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if state.OnDestroy.IsNull() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, 0)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := utils.ContextWithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	remote := state.Remote.ValueString()
+	project := state.Project.ValueString()
+	server, err := r.provider.InstanceServer(remote, project, "")
+	if err != nil {
+		resp.Diagnostics.Append(errors.NewInstanceServerError(err))
+		return
+	}
+
+	instanceName := state.Instance.ValueString()
+	instanceState, _, err := server.GetInstanceState(instanceName)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return
+		}
+
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to retrieve state of instance %q", instanceName), err.Error())
+		return
+	}
+
+	if !isInstanceOperational(*instanceState) {
+		resp.Diagnostics.AddWarning(
+			fmt.Sprintf("Skipped on_destroy command in instance %q", instanceName),
+			fmt.Sprintf("The instance is not running (status: %s).", instanceState.Status),
+		)
+		return
+	}
+
+	var onDestroy InstanceExecOnDestroyModel
+	diags = state.OnDestroy.As(ctx, &onDestroy, basetypes.ObjectAsOptions{})
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	execConfig, diags := common.ToExecConfig(ctx, common.InstanceExecModel{
+		Command:          onDestroy.Command,
+		Environment:      onDestroy.Environment,
+		WorkingDir:       onDestroy.WorkingDir,
+		UserID:           onDestroy.UserID,
+		GroupID:          onDestroy.GroupID,
+		Timeout:          onDestroy.Timeout,
+		AllowedExitCodes: onDestroy.AllowedExitCodes,
+	})
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, diags = runInstanceExec(ctx, server, instanceName, execConfig)
+	resp.Diagnostics.Append(diags...)
+}
+
+// SyncState checks that the instance still exists and applies the provided
+// model as the new state in Terraform. If the instance no longer exists, the
+// resource is removed from the state.
+func (r InstanceExecResource) SyncState(ctx context.Context, tfState *tfsdk.State, server incus.InstanceServer, m InstanceExecModel) diag.Diagnostics {
+	instanceName := m.Instance.ValueString()
+	_, _, err := server.GetInstance(instanceName)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			tfState.RemoveResource(ctx)
+			return nil
+		}
+
+		return diag.Diagnostics{diag.NewErrorDiagnostic(
+			fmt.Sprintf("Failed to retrieve instance %q", instanceName),
+			err.Error(),
+		)}
+	}
+
+	return tfState.Set(ctx, &m)
+}
+
+// run executes the command in the instance and stores the result in the
+// given model.
+func (r InstanceExecResource) run(ctx context.Context, server incus.InstanceServer, m *InstanceExecModel) diag.Diagnostics {
+	execConfig, diags := m.toExecConfig(ctx)
+	if diags.HasError() {
+		return diags
+	}
+
+	result, diags := runInstanceExec(ctx, server, m.Instance.ValueString(), execConfig)
+	if diags.HasError() {
+		return diags
+	}
+
+	m.Stdout = types.StringValue(result.Stdout)
+	m.Stderr = types.StringValue(result.Stderr)
+	m.ExitCode = types.Int64Value(result.ExitCode)
+
+	return nil
+}
+
+// toExecConfig converts the command settings of the model into an exec
+// configuration.
+func (m InstanceExecModel) toExecConfig(ctx context.Context) (common.InstanceExecConfig, diag.Diagnostics) {
+	return common.ToExecConfig(ctx, common.InstanceExecModel{
+		Command:          m.Command,
+		Environment:      m.Environment,
+		WorkingDir:       m.WorkingDir,
+		UserID:           m.UserID,
+		GroupID:          m.GroupID,
+		Timeout:          m.Timeout,
+		AllowedExitCodes: m.AllowedExitCodes,
+	})
+}
+
+// shouldRerunInstanceExec reports whether the command needs to run again,
+// which is the case if the triggers or the command settings have changed.
+// Changes to the allowed exit codes or the on_destroy command do not cause
+// the command to run again.
+func shouldRerunInstanceExec(ctx context.Context, plan InstanceExecModel, state InstanceExecModel) (bool, diag.Diagnostics) {
+	if !plan.Triggers.Equal(state.Triggers) {
+		return true, nil
+	}
+
+	if plan.Command.IsUnknown() || plan.Environment.IsUnknown() || plan.WorkingDir.IsUnknown() ||
+		plan.UserID.IsUnknown() || plan.GroupID.IsUnknown() || plan.Timeout.IsUnknown() {
+		return true, nil
+	}
+
+	planConfig, diags := plan.toExecConfig(ctx)
+	if diags.HasError() {
+		return false, diags
+	}
+
+	stateConfig, diags := state.toExecConfig(ctx)
+	if diags.HasError() {
+		return false, diags
+	}
+
+	return !common.ExecConfigEqual(planConfig, stateConfig), nil
+}
+
+// runInstanceExec waits for the agent of a virtual machine to become ready
+// and runs the command in the instance.
+func runInstanceExec(ctx context.Context, server incus.InstanceServer, instanceName string, execConfig common.InstanceExecConfig) (common.InstanceExecResult, diag.Diagnostics) {
+	inst, _, err := server.GetInstance(instanceName)
+	if err != nil {
+		return common.InstanceExecResult{}, diag.Diagnostics{diag.NewErrorDiagnostic(
+			fmt.Sprintf("Failed to retrieve instance %q", instanceName),
+			err.Error(),
+		)}
+	}
+
+	if inst.Type == "virtual-machine" {
+		diags := waitForInstanceAgent(ctx, server, instanceName)
+		if diags.HasError() {
+			return common.InstanceExecResult{}, diags
+		}
+	}
+
+	result, err := common.RunInstanceExec(ctx, server, instanceName, execConfig)
+	if err != nil {
+		return result, diag.Diagnostics{diag.NewErrorDiagnostic(
+			fmt.Sprintf("Failed to execute command in instance %q", instanceName),
+			formatExecError(err, result.Stdout, result.Stderr),
+		)}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,170 @@
+package instance_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	petname "github.com/dustinkirkland/golang-petname"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/lxc/terraform-provider-incus/internal/acctest"
+)
+
+func TestAccInstanceExec_basic(t *testing.T) {
+	instanceName := petname.Generate(2, "-")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccInstanceExec_basic(instanceName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_instance_exec.exec1", "instance", instanceName),
+					resource.TestCheckResourceAttr("incus_instance_exec.exec1", "stdout", "hello\n"),
+					resource.TestCheckResourceAttr("incus_instance_exec.exec1", "stderr", "oops\n"),
+					resource.TestCheckResourceAttr("incus_instance_exec.exec1", "exit_code", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccInstanceExec_triggers(t *testing.T) {
+	instanceName := petname.Generate(2, "-")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccInstanceExec_counter(instanceName, "v1", "[1]"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_instance_exec.exec1", "stdout", "1\n"),
+				),
+			},
+			{
+				// Changing the allowed exit codes does not run the command again.
+				Config: testAccInstanceExec_counter(instanceName, "v1", "[1, 2]"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_instance_exec.exec1", "stdout", "1\n"),
+					resource.TestCheckResourceAttr("incus_instance_exec.exec1", "allowed_exit_codes.#", "2"),
+				),
+			},
+			{
+				Config: testAccInstanceExec_counter(instanceName, "v2", "[1, 2]"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_instance_exec.exec1", "stdout", "2\n"),
+					resource.TestCheckResourceAttr("incus_instance_exec.exec1", "triggers.version", "v2"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccInstanceExec_onDestroy(t *testing.T) {
+	instanceName := petname.Generate(2, "-")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccInstanceExec_onDestroy(instanceName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_instance_exec.exec1", "on_destroy.command.#", "3"),
+				),
+			},
+			{
+				// Removing the exec resource runs the on_destroy command.
+				Config: testAccInstanceExec_instance(instanceName),
+			},
+			{
+				// The verify command fails, if the on_destroy command did not run.
+				Config: testAccInstanceExec_onDestroyVerify(instanceName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_instance_exec.verify", "exit_code", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccInstanceExec_failure(t *testing.T) {
+	instanceName := petname.Generate(2, "-")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccInstanceExec_failure(instanceName),
+				ExpectError: regexp.MustCompile("exec returned non-zero status 3"),
+			},
+		},
+	})
+}
+
+func testAccInstanceExec_instance(instanceName string) string {
+	return fmt.Sprintf(`
+resource "incus_instance" "instance1" {
+  name  = "%s"
+  image = "%s"
+}
+`, instanceName, acctest.TestImage)
+}
+
+func testAccInstanceExec_basic(instanceName string) string {
+	return fmt.Sprintf(`%s
+resource "incus_instance_exec" "exec1" {
+  instance = incus_instance.instance1.name
+  command  = ["/bin/sh", "-c", "echo hello; echo oops >&2"]
+}
+`, testAccInstanceExec_instance(instanceName))
+}
+
+func testAccInstanceExec_counter(instanceName string, version string, allowedExitCodes string) string {
+	return fmt.Sprintf(`%s
+resource "incus_instance_exec" "exec1" {
+  instance           = incus_instance.instance1.name
+  command            = ["/bin/sh", "-c", "echo $(( $(cat /root/counter 2>/dev/null || echo 0) + 1 )) | tee /root/counter"]
+  allowed_exit_codes = %s
+
+  triggers = {
+    version = "%s"
+  }
+}
+`, testAccInstanceExec_instance(instanceName), allowedExitCodes, version)
+}
+
+func testAccInstanceExec_onDestroy(instanceName string) string {
+	return fmt.Sprintf(`%s
+resource "incus_instance_exec" "exec1" {
+  instance = incus_instance.instance1.name
+  command  = ["/bin/true"]
+
+  on_destroy = {
+    command = ["/bin/sh", "-c", "touch /root/destroyed"]
+  }
+}
+`, testAccInstanceExec_instance(instanceName))
+}
+
+func testAccInstanceExec_onDestroyVerify(instanceName string) string {
+	return fmt.Sprintf(`%s
+resource "incus_instance_exec" "verify" {
+  instance = incus_instance.instance1.name
+  command  = ["/bin/sh", "-c", "test -f /root/destroyed"]
+}
+`, testAccInstanceExec_instance(instanceName))
+}
+
+func testAccInstanceExec_failure(instanceName string) string {
+	return fmt.Sprintf(`%s
+resource "incus_instance_exec" "exec1" {
+  instance = incus_instance.instance1.name
+  command  = ["/bin/sh", "-c", "exit 3"]
+}
+`, testAccInstanceExec_instance(instanceName))
+}
@@ -0,0 +1,529 @@
+package instance
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	incus "github.com/lxc/incus/v7/client"
+	"github.com/mitchellh/go-homedir"
+
+	"github.com/lxc/terraform-provider-incus/internal/common"
+	"github.com/lxc/terraform-provider-incus/internal/errors"
+	provider_config "github.com/lxc/terraform-provider-incus/internal/provider-config"
+)
+
+var (
+	absolutePathRegexp = regexp.MustCompile(`^/.+`)
+	fileModeRegexp     = regexp.MustCompile(`^[0-7]{3,4}$`)
+)
+
+type InstanceFileModel struct {
+	Instance      types.String `tfsdk:"instance"`
+	TargetPath    types.String `tfsdk:"target_path"`
+	Content       types.String `tfsdk:"content"`
+	SourcePath    types.String `tfsdk:"source_path"`
+	UserID        types.Int64  `tfsdk:"uid"`
+	GroupID       types.Int64  `tfsdk:"gid"`
+	Mode          types.String `tfsdk:"mode"`
+	CreateDirs    types.Bool   `tfsdk:"create_directories"`
+	DirectoryMode types.String `tfsdk:"directory_mode"`
+	Project       types.String `tfsdk:"project"`
+	Remote        types.String `tfsdk:"remote"`
+
+	// Computed.
+	ContentSHA256 types.String `tfsdk:"content_sha256"`
+}
+
+// InstanceFileResource represent Incus instance file resource.
+type InstanceFileResource struct {
+	provider *provider_config.IncusProviderConfig
+}
+
+// NewInstanceFileResource returns a new instance file resource.
+func NewInstanceFileResource() resource.Resource {
+	return &InstanceFileResource{}
+}
+
+func (r InstanceFileResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_instance_file", req.ProviderTypeName)
+}
+
+func (r InstanceFileResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"instance": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+
+			"target_path": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(absolutePathRegexp, "must be an absolute path"),
+				},
+			},
+
+			"content": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("source_path")),
+				},
+			},
+
+			"source_path": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+
+			"uid": schema.Int64Attribute{
+				Optional: true,
+				Computed: true,
+				Default:  int64default.StaticInt64(0),
+			},
+
+			"gid": schema.Int64Attribute{
+				Optional: true,
+				Computed: true,
+				Default:  int64default.StaticInt64(0),
+			},
+
+			"mode": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString("0755"),
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(fileModeRegexp, "must be an octal file mode"),
+				},
+			},
+
+			"create_directories": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+
+			"directory_mode": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(fileModeRegexp, "must be an octal file mode"),
+				},
+			},
+
+			"project": schema.StringAttribute{
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+
+			"remote": schema.StringAttribute{
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+
+			// Computed.
+
+			"content_sha256": schema.StringAttribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (r *InstanceFileResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	data := req.ProviderData
+	if data == nil {
+		return
+	}
+
+	provider, ok := data.(*provider_config.IncusProviderConfig)
+	if !ok {
+		resp.Diagnostics.Append(errors.NewProviderDataTypeError(req.ProviderData))
+		return
+	}
+
+	r.provider = provider
+}
+
+// ModifyPlan sets the expected content hash, which is compared against the
+// hash of the file in the instance. If they differ, the file is uploaded
+// again.
+func (r InstanceFileResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do on destroy.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan InstanceFileModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	contentHash := types.StringUnknown()
+	switch {
+	case plan.Content.IsUnknown() || plan.SourcePath.IsUnknown():
+		// Content is not known until apply.
+	case !plan.Content.IsNull():
+		contentHash = types.StringValue(contentSHA256(strings.NewReader(plan.Content.ValueString())))
+	case !plan.SourcePath.IsNull():
+		hash, err := sourceFileSHA256(plan.SourcePath.ValueString())
+		if err == nil {
+			contentHash = types.StringValue(hash)
+		}
+	}
+
+	resp.Plan.SetAttribute(ctx, path.Root("content_sha256"), contentHash)
+}
+
+func (r InstanceFileResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan InstanceFileModel
+
+	// Fetch resource model from Terraform plan.
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	remote := plan.Remote.ValueString()
+	project := plan.Project.ValueString()
+	server, err := r.provider.InstanceServer(remote, project, "")
+	if err != nil {
+		resp.Diagnostics.Append(errors.NewInstanceServerError(err))
+		return
+	}
+
+	instanceName := plan.Instance.ValueString()
+	err = common.InstanceFileUpload(server, instanceName, plan.toFileModel())
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to upload file to instance %q", instanceName), err.Error())
+		return
+	}
+
+	// Update Terraform state.
+	diags = r.SyncState(ctx, &resp.State, server, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r InstanceFileResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state InstanceFileModel
+
+	// Fetch resource model from Terraform state.
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	remote := state.Remote.ValueString()
+	project := state.Project.ValueString()
+	server, err := r.provider.InstanceServer(remote, project, "")
+	if err != nil {
+		resp.Diagnostics.Append(errors.NewInstanceServerError(err))
+		return
+	}
+
+	// Files can only be read reliably from running instances. Otherwise,
+	// the prior state is kept.
+	instanceName := state.Instance.ValueString()
+	instanceState, _, err := server.GetInstanceState(instanceName)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to retrieve state of instance %q", instanceName), err.Error())
+		return
+	}
+
+	if !isInstanceOperational(*instanceState) {
+		resp.Diagnostics.AddWarning(
+			fmt.Sprintf("Skipped refresh of file %q in instance %q", state.TargetPath.ValueString(), instanceName),
+			fmt.Sprintf("The instance is not running (status: %s).", instanceState.Status),
+		)
+		return
+	}
+
+	// Update Terraform state.
+	diags = r.SyncState(ctx, &resp.State, server, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r InstanceFileResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan InstanceFileModel
+
+	// Fetch resource model from Terraform plan.
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	remote := plan.Remote.ValueString()
+	project := plan.Project.ValueString()
+	server, err := r.provider.InstanceServer(remote, project, "")
+	if err != nil {
+		resp.Diagnostics.Append(errors.NewInstanceServerError(err))
+		return
+	}
+
+	// Delete the old file first otherwise mode and ownership changes
+	// will not be applied.
+	instanceName := plan.Instance.ValueString()
+	targetPath := plan.TargetPath.ValueString()
+
+	fileTarget := common.NewInstanceFileTarget(server, instanceName)
+	defer fileTarget.Close()
+
+	err = fileTarget.Delete(targetPath)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to delete file from instance %q", instanceName), err.Error())
+		return
+	}
+
+	err = fileTarget.Upload(plan.toFileModel())
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to upload updated file to instance %q", instanceName), err.Error())
+		return
+	}
+
+	// Update Terraform state.
+	diags = r.SyncState(ctx, &resp.State, server, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r InstanceFileResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state InstanceFileModel
+
+	// Fetch resource model from Terraform state.
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	remote := state.Remote.ValueString()
+	project := state.Project.ValueString()
+	server, err := r.provider.InstanceServer(remote, project, "")
+	if err != nil {
+		resp.Diagnostics.Append(errors.NewInstanceServerError(err))
+		return
+	}
+
+	instanceName := state.Instance.ValueString()
+	targetPath := state.TargetPath.ValueString()
+	err = common.InstanceFileDelete(server, instanceName, targetPath)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to delete file %q from instance %q", targetPath, instanceName), err.Error())
+	}
+}
+
+// ImportState imports a file using an import ID in the format
+// [remote:]instance/path[,project=<project>].
+func (r InstanceFileResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	fields, err := parseInstanceFileImportID(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			fmt.Sprintf("Valid import format:\nimport incus_instance_file.<resource> [<remote>:]<instance>/<path>[,project=<value>]\n\n%v", err),
+		)
+		return
+	}
+
+	for k, v := range fields {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(k), v)...)
+	}
+}
+
+// SyncState fetches the file from the instance and updates the provided
+// model. It then applies this updated model as the new state in Terraform.
+// If the file or the instance no longer exists, the resource is removed
+// from the state.
+func (r InstanceFileResource) SyncState(ctx context.Context, tfState *tfsdk.State, server incus.InstanceServer, m InstanceFileModel) diag.Diagnostics {
+	instanceName := m.Instance.ValueString()
+	targetPath := m.TargetPath.ValueString()
+
+	content, file, err := server.GetInstanceFile(instanceName, targetPath)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			tfState.RemoveResource(ctx)
+			return nil
+		}
+
+		return diag.Diagnostics{diag.NewErrorDiagnostic(
+			fmt.Sprintf("Failed to retrieve file %q from instance %q", targetPath, instanceName),
+			err.Error(),
+		)}
+	}
+
+	defer content.Close()
+
+	if file.Type != "file" {
+		return diag.Diagnostics{diag.NewErrorDiagnostic(
+			fmt.Sprintf("Failed to retrieve file %q from instance %q", targetPath, instanceName),
+			fmt.Sprintf("Path is a %s and not a file", file.Type),
+		)}
+	}
+
+	data, err := io.ReadAll(content)
+	if err != nil {
+		return diag.Diagnostics{diag.NewErrorDiagnostic(
+			fmt.Sprintf("Failed to read file %q from instance %q", targetPath, instanceName),
+			err.Error(),
+		)}
+	}
+
+	// On import, neither content nor source path is known. Text files are
+	// imported with their content.
+	if m.Content.IsNull() && m.SourcePath.IsNull() && utf8.Valid(data) {
+		m.Content = types.StringValue(string(data))
+	}
+
+	// Keep the configured mode, if it matches the actual mode, so that
+	// different notations, such as "644" and "0644", do not cause a diff.
+	configuredMode, err := strconv.ParseUint(m.Mode.ValueString(), 8, 32)
+	if err != nil || int(configuredMode) != file.Mode {
+		m.Mode = types.StringValue(fmt.Sprintf("%04o", file.Mode))
+	}
+
+	m.UserID = types.Int64Value(file.UID)
+	m.GroupID = types.Int64Value(file.GID)
+	m.ContentSHA256 = types.StringValue(contentSHA256(strings.NewReader(string(data))))
+
+	if m.CreateDirs.IsNull() {
+		m.CreateDirs = types.BoolValue(false)
+	}
+
+	return tfState.Set(ctx, &m)
+}
+
+// toFileModel converts the model into a file model that can be uploaded
+// using common.InstanceFileUpload.
+func (m InstanceFileModel) toFileModel() common.InstanceFileModel {
+	return common.InstanceFileModel{
+		Content:       m.Content,
+		SourcePath:    m.SourcePath,
+		TargetPath:    m.TargetPath,
+		UserID:        m.UserID,
+		GroupID:       m.GroupID,
+		Mode:          m.Mode,
+		DirectoryMode: m.DirectoryMode,
+		CreateDirs:    m.CreateDirs,
+		Append:        types.BoolValue(false),
+	}
+}
+
+// parseInstanceFileImportID parses an import ID in the format
+// [remote:]instance/path[,project=<project>]. Unlike other import IDs, the
+// project is passed as an option, because the path itself contains slashes.
+func parseInstanceFileImportID(importID string) (map[string]string, error) {
+	result := make(map[string]string)
+
+	id, options, _ := strings.Cut(importID, ",")
+	for _, option := range strings.Split(options, ",") {
+		if option == "" {
+			continue
+		}
+
+		key, value, ok := strings.Cut(option, "=")
+		if !ok || key != "project" || value == "" {
+			return nil, fmt.Errorf("Import ID contains unexpected option %q", option)
+		}
+
+		result["project"] = value
+	}
+
+	// The remote can only be followed by the instance name, so a colon
+	// after the first slash is part of the path.
+	slash := strings.Index(id, "/")
+	if slash < 0 {
+		return nil, fmt.Errorf("Import ID requires the path of the file")
+	}
+
+	remote, instanceName, ok := strings.Cut(id[:slash], ":")
+	if ok {
+		if remote != "" {
+			result["remote"] = remote
+		}
+	} else {
+		instanceName = remote
+	}
+
+	targetPath := id[slash:]
+	if instanceName == "" {
+		return nil, fmt.Errorf("Import ID requires non-empty value for %q", "instance")
+	}
+
+	if targetPath == "/" {
+		return nil, fmt.Errorf("Import ID requires non-empty value for %q", "target_path")
+	}
+
+	result["instance"] = instanceName
+	result["target_path"] = targetPath
+
+	return result, nil
+}
+
+// contentSHA256 returns the hex encoded SHA-256 hash of the given content.
+func contentSHA256(content io.Reader) string {
+	hash := sha256.New()
+	_, _ = io.Copy(hash, content)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// sourceFileSHA256 returns the hex encoded SHA-256 hash of a local file.
+func sourceFileSHA256(sourcePath string) (string, error) {
+	sourcePath, err := homedir.Expand(sourcePath)
+	if err != nil {
+		return "", err
+	}
+
+	f, err := os.Open(sourcePath)
+	if err != nil {
+		return "", err
+	}
+
+	defer f.Close()
+
+	return contentSHA256(f), nil
+}
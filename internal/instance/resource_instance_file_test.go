@@ -0,0 +1,157 @@
+package instance_test
+
+import (
+	"fmt"
+	"testing"
+
+	petname "github.com/dustinkirkland/golang-petname"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+
+	"github.com/lxc/terraform-provider-incus/internal/acctest"
+)
+
+func TestAccInstanceFile_basic(t *testing.T) {
+	instanceName := petname.Generate(2, "-")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccInstanceFile_content(instanceName, "Hello, World!\n", "0644"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_instance_file.file1", "instance", instanceName),
+					resource.TestCheckResourceAttr("incus_instance_file.file1", "target_path", "/foo/bar.txt"),
+					resource.TestCheckResourceAttr("incus_instance_file.file1", "content", "Hello, World!\n"),
+					resource.TestCheckResourceAttr("incus_instance_file.file1", "mode", "0644"),
+					resource.TestCheckResourceAttr("incus_instance_file.file1", "uid", "0"),
+					resource.TestCheckResourceAttr("incus_instance_file.file1", "gid", "0"),
+					resource.TestCheckResourceAttr("incus_instance_file.file1", "content_sha256", "c98c24b677eff44860afea6f493bbaec5bb1c4cbb209c6fc2bbb47f66ff2ad31"),
+				),
+			},
+			{
+				Config: testAccInstanceFile_content(instanceName, "Goodbye, World!\n", "0600"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_instance_file.file1", "content", "Goodbye, World!\n"),
+					resource.TestCheckResourceAttr("incus_instance_file.file1", "mode", "0600"),
+				),
+			},
+			{
+				ResourceName:                         "incus_instance_file.file1",
+				ImportState:                          true,
+				ImportStateId:                        fmt.Sprintf("%s/foo/bar.txt", instanceName),
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "target_path",
+				ImportStateVerifyIgnore:              []string{"create_directories"},
+			},
+		},
+	})
+}
+
+func TestAccInstanceFile_drift(t *testing.T) {
+	instanceName := petname.Generate(2, "-")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccInstanceFile_content(instanceName, "Hello, World!\n", "0644"),
+			},
+			{
+				// Change the file inside the instance.
+				Config: testAccInstanceFile_contentWithExec(instanceName, "Hello, World!\n", "0644", "echo changed > /foo/bar.txt && chmod 0600 /foo/bar.txt"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PostApplyPostRefresh: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("incus_instance_file.file1", plancheck.ResourceActionUpdate),
+					},
+				},
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				// The file is uploaded again.
+				Config: testAccInstanceFile_contentWithExec(instanceName, "Hello, World!\n", "0644", "echo changed > /foo/bar.txt && chmod 0600 /foo/bar.txt"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("incus_instance_file.file1", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_instance_file.file1", "mode", "0644"),
+					resource.TestCheckResourceAttr("incus_instance_file.file1", "content_sha256", "c98c24b677eff44860afea6f493bbaec5bb1c4cbb209c6fc2bbb47f66ff2ad31"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccInstanceFile_stoppedInstance(t *testing.T) {
+	instanceName := petname.Generate(2, "-")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccInstanceFile_desiredState(instanceName, "running"),
+			},
+			{
+				// The file cannot be read, so the prior state is kept.
+				Config: testAccInstanceFile_desiredState(instanceName, "stopped"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_instance.instance1", "status", "Stopped"),
+					resource.TestCheckResourceAttr("incus_instance_file.file1", "content", "Hello, World!\n"),
+					resource.TestCheckResourceAttr("incus_instance_file.file1", "mode", "0644"),
+					resource.TestCheckResourceAttr("incus_instance_file.file1", "content_sha256", "c98c24b677eff44860afea6f493bbaec5bb1c4cbb209c6fc2bbb47f66ff2ad31"),
+				),
+			},
+		},
+	})
+}
+
+func testAccInstanceFile_content(instanceName string, content string, mode string) string {
+	return fmt.Sprintf(`
+resource "incus_instance" "instance1" {
+  name  = "%s"
+  image = "%s"
+}
+
+resource "incus_instance_file" "file1" {
+  instance           = incus_instance.instance1.name
+  target_path        = "/foo/bar.txt"
+  content            = %q
+  mode               = "%s"
+  create_directories = true
+}
+`, instanceName, acctest.TestImage, content, mode)
+}
+
+func testAccInstanceFile_contentWithExec(instanceName string, content string, mode string, command string) string {
+	return fmt.Sprintf(`%s
+resource "incus_instance_exec" "change" {
+  instance = incus_instance.instance1.name
+  command  = ["/bin/sh", "-c", %q]
+
+  depends_on = [incus_instance_file.file1]
+}
+`, testAccInstanceFile_content(instanceName, content, mode), command)
+}
+
+func testAccInstanceFile_desiredState(instanceName string, desiredState string) string {
+	return fmt.Sprintf(`
+resource "incus_instance" "instance1" {
+  name          = "%s"
+  image         = "%s"
+  desired_state = "%s"
+}
+
+resource "incus_instance_file" "file1" {
+  instance           = incus_instance.instance1.name
+  target_path        = "/foo/bar.txt"
+  content            = "Hello, World!\n"
+  mode               = "0644"
+  create_directories = true
+}
+`, instanceName, acctest.TestImage, desiredState)
+}
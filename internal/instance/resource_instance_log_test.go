@@ -0,0 +1,56 @@
+package instance
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestInstance_appendLogSection(t *testing.T) {
+	longLines := make([]string, 0, instanceLogLines+10)
+	for i := 1; i <= instanceLogLines+10; i++ {
+		longLines = append(longLines, fmt.Sprintf("line %d", i))
+	}
+
+	tests := []struct {
+		name     string
+		log      string
+		expected []string
+	}{
+		{
+			name:     "empty log",
+			log:      "\n\n",
+			expected: nil,
+		},
+		{
+			name:     "short log",
+			log:      "first\r\nsecond\n",
+			expected: []string{"Console log:\nfirst\nsecond"},
+		},
+		{
+			name:     "colored log",
+			log:      "\x1b[32mok\x1b[0m\n",
+			expected: []string{"Console log:\nok"},
+		},
+		{
+			name:     "truncated log",
+			log:      strings.Join(longLines, "\n"),
+			expected: []string{fmt.Sprintf("Console log (last %d lines):\n%s", instanceLogLines, strings.Join(longLines[10:], "\n"))},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := appendLogSection(nil, "Console log", test.log)
+			if len(got) != len(test.expected) {
+				t.Fatalf("Expected %d sections, got %d: %q", len(test.expected), len(got), got)
+			}
+
+			for i := range got {
+				if got[i] != test.expected[i] {
+					t.Fatalf("Expected section %q, got %q", test.expected[i], got[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package instance
+
+import (
+	"testing"
+)
+
+func TestInstance_isPortListening(t *testing.T) {
+	const header = "  sl  local_address rem_address   st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode"
+
+	tests := []struct {
+		name     string
+		contents string
+		port     int64
+		expected bool
+	}{
+		{
+			name: "listening IPv4",
+			contents: header + `
+   0: 00000000:0016 00000000:0000 0A 00000000:00000000 00:00000000 00000000     0        0 12345 1 0000000000000000 100 0 0 10 0`,
+			port:     22,
+			expected: true,
+		},
+		{
+			name: "established only",
+			contents: header + `
+   0: 0100007F:0016 0100007F:D431 01 00000000:00000000 00:00000000 00000000     0        0 12345 1 0000000000000000 20 4 30 10 -1`,
+			port:     22,
+			expected: false,
+		},
+		{
+			name: "established and listening",
+			contents: header + `
+   0: 0100007F:1F90 0100007F:D431 01 00000000:00000000 00:00000000 00000000     0        0 12345 1 0000000000000000 20 4 30 10 -1
+   1: 00000000:1F90 00000000:0000 0A 00000000:00000000 00:00000000 00000000     0        0 12346 1 0000000000000000 100 0 0 10 0`,
+			port:     8080,
+			expected: true,
+		},
+		{
+			name: "listening IPv6",
+			contents: header + `
+   0: 00000000000000000000000000000000:01BB 00000000000000000000000000000000:0000 0A 00000000:00000000 00:00000000 00000000     0        0 12345 1 0000000000000000 100 0 0 10 0`,
+			port:     443,
+			expected: true,
+		},
+		{
+			name: "port in lowercase hex",
+			contents: header + `
+   0: 00000000:1f90 00000000:0000 0A 00000000:00000000 00:00000000 00000000     0        0 12345 1 0000000000000000 100 0 0 10 0`,
+			port:     8080,
+			expected: true,
+		},
+		{
+			name: "port is not parsed as decimal",
+			contents: header + `
+   0: 00000000:0050 00000000:0000 0A 00000000:00000000 00:00000000 00000000     0        0 12345 1 0000000000000000 100 0 0 10 0`,
+			port:     50,
+			expected: false,
+		},
+		{
+			name: "different port",
+			contents: header + `
+   0: 00000000:0016 00000000:0000 0A 00000000:00000000 00:00000000 00000000     0        0 12345 1 0000000000000000 100 0 0 10 0`,
+			port:     80,
+			expected: false,
+		},
+		{
+			name: "malformed lines",
+			contents: header + `
+   0: 00000000
+   1: 000000000016 00000000:0000 0A
+   2: 00000000:ZZZZ 00000000:0000 0A
+   3: 00000000:0016 00000000:0000 0A 00000000:00000000 00:00000000 00000000     0        0 12345 1 0000000000000000 100 0 0 10 0`,
+			port:     22,
+			expected: true,
+		},
+		{
+			name:     "malformed lines only",
+			contents: "garbage\n0: 00000000:ZZZZ 00000000:0000 0A\n\n",
+			port:     22,
+			expected: false,
+		},
+		{
+			name:     "empty",
+			contents: "",
+			port:     22,
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := isPortListening(tt.contents, tt.port)
+			if actual != tt.expected {
+				t.Fatalf("isPortListening(%q, %d) = %t, want %t", tt.contents, tt.port, actual, tt.expected)
+			}
+		})
+	}
+}
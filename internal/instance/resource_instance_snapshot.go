@@ -4,16 +4,20 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
@@ -21,21 +25,30 @@ import (
 	incus "github.com/lxc/incus/v7/client"
 	"github.com/lxc/incus/v7/shared/api"
 
+	"github.com/lxc/terraform-provider-incus/internal/common"
 	"github.com/lxc/terraform-provider-incus/internal/errors"
 	provider_config "github.com/lxc/terraform-provider-incus/internal/provider-config"
+	"github.com/lxc/terraform-provider-incus/internal/utils"
 )
 
 type InstanceSnapshotModel struct {
-	Name     types.String `tfsdk:"name"`
-	Instance types.String `tfsdk:"instance"`
-	Stateful types.Bool   `tfsdk:"stateful"`
-	Project  types.String `tfsdk:"project"`
-	Remote   types.String `tfsdk:"remote"`
+	Name        types.String   `tfsdk:"name"`
+	Instance    types.String   `tfsdk:"instance"`
+	Description types.String   `tfsdk:"description"`
+	ExpiresAt   types.String   `tfsdk:"expires_at"`
+	Stateful    types.Bool     `tfsdk:"stateful"`
+	Project     types.String   `tfsdk:"project"`
+	Remote      types.String   `tfsdk:"remote"`
+	Timeouts    timeouts.Value `tfsdk:"timeouts"`
 
 	// Computed.
 	CreatedAt types.Int64 `tfsdk:"created_at"`
 }
 
+// instanceSnapshotExpiryKey is the private state key recording whether
+// expires_at is set in the configuration.
+const instanceSnapshotExpiryKey = "expires_at_configured"
+
 // InstanceSnapshotResource represent Incus instance snapshot resource.
 type InstanceSnapshotResource struct {
 	provider *provider_config.IncusProviderConfig
@@ -50,13 +63,13 @@ func (r InstanceSnapshotResource) Metadata(_ context.Context, req resource.Metad
 	resp.TypeName = fmt.Sprintf("%s_instance_snapshot", req.ProviderTypeName)
 }
 
-func (r InstanceSnapshotResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r InstanceSnapshotResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"name": schema.StringAttribute{
 				Required: true,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
 				},
 			},
 
@@ -67,6 +80,23 @@ func (r InstanceSnapshotResource) Schema(_ context.Context, _ resource.SchemaReq
 				},
 			},
 
+			"description": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(""),
+			},
+
+			"expires_at": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					common.TimestampValidator{},
+				},
+			},
+
 			"stateful": schema.BoolAttribute{
 				Optional: true,
 				Computed: true,
@@ -99,6 +129,14 @@ func (r InstanceSnapshotResource) Schema(_ context.Context, _ resource.SchemaReq
 				Computed: true,
 			},
 		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Delete: true,
+			}),
+		},
 	}
 }
 
@@ -117,6 +155,32 @@ func (r *InstanceSnapshotResource) Configure(_ context.Context, req resource.Con
 	r.provider = provider
 }
 
+// ModifyPlan clears the expiry when a previously configured expires_at is
+// removed from the configuration. An expiry inherited from the instance's
+// "snapshots.expiry" setting is kept.
+func (r InstanceSnapshotResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do on create or destroy.
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var expiresAt types.String
+
+	diags := req.Config.GetAttribute(ctx, path.Root("expires_at"), &expiresAt)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || !expiresAt.IsNull() {
+		return
+	}
+
+	configured, diags := req.Private.GetKey(ctx, instanceSnapshotExpiryKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || string(configured) != "true" {
+		return
+	}
+
+	resp.Plan.SetAttribute(ctx, path.Root("expires_at"), types.StringNull())
+}
+
 func (r InstanceSnapshotResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var plan InstanceSnapshotModel
 
@@ -127,6 +191,15 @@ func (r InstanceSnapshotResource) Create(ctx context.Context, req resource.Creat
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, 0)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := utils.ContextWithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	remote := plan.Remote.ValueString()
 	project := plan.Project.ValueString()
 	server, err := r.provider.InstanceServer(remote, project, "")
@@ -138,38 +211,42 @@ func (r InstanceSnapshotResource) Create(ctx context.Context, req resource.Creat
 	instanceName := plan.Instance.ValueString()
 	snapshotName := plan.Name.ValueString()
 
+	expiresAt, err := common.ToTimestamp(plan.ExpiresAt)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("expires_at"), "Invalid timestamp", err.Error())
+		return
+	}
+
 	snapshotReq := api.InstanceSnapshotsPost{
-		Name:     snapshotName,
-		Stateful: plan.Stateful.ValueBool(),
+		Name:      snapshotName,
+		Stateful:  plan.Stateful.ValueBool(),
+		ExpiresAt: expiresAt,
 	}
 
-	var serr error
-	for i := 0; i < 5; i++ {
-		op, err := server.CreateInstanceSnapshot(instanceName, snapshotReq)
-		if err != nil {
-			resp.Diagnostics.AddError(fmt.Sprintf("Failed to create snapshot %q for instance %q", snapshotName, instanceName), serr.Error())
-			return
-		}
+	err = createInstanceSnapshot(ctx, server, instanceName, snapshotReq)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to create snapshot %q for instance %q", snapshotName, instanceName), err.Error())
+		return
+	}
 
-		// Wait for snapshot operation to complete.
-		serr = op.Wait()
-		if serr == nil {
-			break
+	// Snapshot description can only be set once the snapshot exists.
+	if plan.Description.ValueString() != "" {
+		diags = r.updateSnapshot(ctx, server, plan)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
 		}
+	}
 
-		if snapshotReq.Stateful && strings.Contains(serr.Error(), "Dumping FAILED") {
-			log.Printf("[DEBUG] Error creating stateful snapshot [retry %d]: %v", i, serr)
-			time.Sleep(3 * time.Second)
-		} else if strings.Contains(serr.Error(), "file has vanished") {
-			// Ignore, try again.
-			time.Sleep(3 * time.Second)
-		} else {
-			break
-		}
+	configured, diags := instanceSnapshotExpiryConfigured(ctx, req.Config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	if serr != nil {
-		resp.Diagnostics.AddError(fmt.Sprintf("Failed to create snapshot %q for instance %q", snapshotName, instanceName), serr.Error())
+	diags = resp.Private.SetKey(ctx, instanceSnapshotExpiryKey, configured)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
@@ -202,6 +279,79 @@ func (r InstanceSnapshotResource) Read(ctx context.Context, req resource.ReadReq
 }
 
 func (r InstanceSnapshotResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan InstanceSnapshotModel
+	var state InstanceSnapshotModel
+
+	// Fetch resource model from Terraform plan.
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, 0)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := utils.ContextWithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	remote := plan.Remote.ValueString()
+	project := plan.Project.ValueString()
+	server, err := r.provider.InstanceServer(remote, project, "")
+	if err != nil {
+		resp.Diagnostics.Append(errors.NewInstanceServerError(err))
+		return
+	}
+
+	instanceName := plan.Instance.ValueString()
+	oldSnapshotName := state.Name.ValueString()
+	newSnapshotName := plan.Name.ValueString()
+
+	// Rename the snapshot.
+	if oldSnapshotName != newSnapshotName {
+		renameReq := api.InstanceSnapshotPost{
+			Name: newSnapshotName,
+		}
+
+		op, err := server.RenameInstanceSnapshot(instanceName, oldSnapshotName, renameReq)
+		if err == nil {
+			err = op.WaitContext(ctx)
+		}
+
+		if err != nil {
+			resp.Diagnostics.AddError(fmt.Sprintf("Failed to rename snapshot %q for instance %q to %q", oldSnapshotName, instanceName, newSnapshotName), err.Error())
+			return
+		}
+	}
+
+	diags = r.updateSnapshot(ctx, server, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	configured, diags := instanceSnapshotExpiryConfigured(ctx, req.Config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.Private.SetKey(ctx, instanceSnapshotExpiryKey, configured)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Update Terraform state.
+	diags = r.SyncState(ctx, &resp.State, server, plan)
+	resp.Diagnostics.Append(diags...)
 }
 
 func (r InstanceSnapshotResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -214,6 +364,15 @@ func (r InstanceSnapshotResource) Delete(ctx context.Context, req resource.Delet
 		return
 	}
 
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, 0)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := utils.ContextWithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	remote := state.Remote.ValueString()
 	project := state.Project.ValueString()
 	server, err := r.provider.InstanceServer(remote, project, "")
@@ -230,12 +389,88 @@ func (r InstanceSnapshotResource) Delete(ctx context.Context, req resource.Delet
 		return
 	}
 
-	err = op.Wait()
+	err = op.WaitContext(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError(fmt.Sprintf("Failed to remove snapshot %q for instance %q", snapshotName, instanceName), err.Error())
 	}
 }
 
+func (r InstanceSnapshotResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	meta := common.ImportMetadata{
+		ResourceName:   "instance_snapshot",
+		RequiredFields: []string{"instance", "name"},
+	}
+
+	fields, diag := meta.ParseImportID(req.ID)
+	if diag != nil {
+		resp.Diagnostics.Append(diag)
+		return
+	}
+
+	for k, v := range fields {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(k), v)...)
+	}
+}
+
+// updateSnapshot applies the description and expiry from the provided
+// model to an existing instance snapshot.
+func (r InstanceSnapshotResource) updateSnapshot(ctx context.Context, server incus.InstanceServer, m InstanceSnapshotModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	instanceName := m.Instance.ValueString()
+	snapshotName := m.Name.ValueString()
+
+	snapshot, etag, err := server.GetInstanceSnapshot(instanceName, snapshotName)
+	if err != nil {
+		diags.AddError(fmt.Sprintf("Failed to retrieve snapshot %q for instance %q", snapshotName, instanceName), err.Error())
+		return diags
+	}
+
+	snapshotReq := snapshot.InstanceSnapshotPut
+	snapshotReq.Description = m.Description.ValueString()
+
+	// Keep the existing expiry unless a new one is provided. A null expiry
+	// is only planned when a configured one is removed, in which case the
+	// zero time clears it.
+	if !m.ExpiresAt.IsUnknown() {
+		expiresAt, err := common.ToTimestamp(m.ExpiresAt)
+		if err != nil {
+			diags.AddAttributeError(path.Root("expires_at"), "Invalid timestamp", err.Error())
+			return diags
+		}
+
+		snapshotReq.ExpiresAt = time.Time{}
+		if expiresAt != nil {
+			snapshotReq.ExpiresAt = *expiresAt
+		}
+	}
+
+	op, err := server.UpdateInstanceSnapshot(instanceName, snapshotName, snapshotReq, etag)
+	if err == nil {
+		err = op.WaitContext(ctx)
+	}
+
+	if err != nil {
+		diags.AddError(fmt.Sprintf("Failed to update snapshot %q for instance %q", snapshotName, instanceName), err.Error())
+	}
+
+	return diags
+}
+
+// instanceSnapshotExpiryConfigured returns whether expires_at is set in the
+// configuration, encoded for the private state. ModifyPlan uses it to tell a
+// removed expiry apart from one inherited from the instance.
+func instanceSnapshotExpiryConfigured(ctx context.Context, config tfsdk.Config) ([]byte, diag.Diagnostics) {
+	var expiresAt types.String
+
+	diags := config.GetAttribute(ctx, path.Root("expires_at"), &expiresAt)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	return []byte(strconv.FormatBool(!expiresAt.IsNull())), nil
+}
+
 // SyncState fetches the server's current state for an instance snapshot and
 // updates the provided model. It then applies this updated model as the new
 // state in Terraform.
@@ -255,8 +490,41 @@ func (r InstanceSnapshotResource) SyncState(ctx context.Context, tfState *tfsdk.
 		)}
 	}
 
+	m.Description = types.StringValue(snapshot.Description)
+	m.ExpiresAt = common.ToTimestampType(&snapshot.ExpiresAt, m.ExpiresAt)
 	m.Stateful = types.BoolValue(snapshot.Stateful)
 	m.CreatedAt = types.Int64Value(snapshot.CreatedAt.Unix())
 
 	return tfState.Set(ctx, &m)
 }
+
+// createInstanceSnapshot creates a snapshot of the instance with the given
+// name. Stateful snapshots and snapshots of busy instances can fail
+// transiently, in which case the snapshot is retried a few times.
+func createInstanceSnapshot(ctx context.Context, server incus.InstanceServer, instanceName string, req api.InstanceSnapshotsPost) error {
+	var serr error
+	for i := 0; i < 5; i++ {
+		op, err := server.CreateInstanceSnapshot(instanceName, req)
+		if err != nil {
+			return err
+		}
+
+		// Wait for snapshot operation to complete.
+		serr = op.WaitContext(ctx)
+		if serr == nil {
+			break
+		}
+
+		if req.Stateful && strings.Contains(serr.Error(), "Dumping FAILED") {
+			log.Printf("[DEBUG] Error creating stateful snapshot [retry %d]: %v", i, serr)
+			time.Sleep(3 * time.Second)
+		} else if strings.Contains(serr.Error(), "file has vanished") {
+			// Ignore, try again.
+			time.Sleep(3 * time.Second)
+		} else {
+			break
+		}
+	}
+
+	return serr
+}
@@ -0,0 +1,442 @@
+package instance
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	incus "github.com/lxc/incus/v7/client"
+	"github.com/lxc/incus/v7/shared/api"
+
+	"github.com/lxc/terraform-provider-incus/internal/errors"
+	provider_config "github.com/lxc/terraform-provider-incus/internal/provider-config"
+	"github.com/lxc/terraform-provider-incus/internal/utils"
+)
+
+var snapshotNamePrefixRegexp = regexp.MustCompile(`^[^/]+$`)
+
+// policySnapshotSuffixRegexp matches the part of a snapshot name that
+// policySnapshotName appends to the name prefix.
+var policySnapshotSuffixRegexp = regexp.MustCompile(`^\d{8}-\d{6}(-\d+)?$`)
+
+type InstanceSnapshotPolicyModel struct {
+	Instance   types.String   `tfsdk:"instance"`
+	NamePrefix types.String   `tfsdk:"name_prefix"`
+	KeepLast   types.Int64    `tfsdk:"keep_last"`
+	Stateful   types.Bool     `tfsdk:"stateful"`
+	Trigger    types.String   `tfsdk:"trigger"`
+	Project    types.String   `tfsdk:"project"`
+	Remote     types.String   `tfsdk:"remote"`
+	Timeouts   timeouts.Value `tfsdk:"timeouts"`
+
+	// Computed.
+	Snapshots types.List `tfsdk:"snapshots"`
+}
+
+// InstanceSnapshotPolicyResource represent Incus instance snapshot policy
+// resource.
+type InstanceSnapshotPolicyResource struct {
+	provider *provider_config.IncusProviderConfig
+}
+
+// NewInstanceSnapshotPolicyResource returns a new instance snapshot policy
+// resource.
+func NewInstanceSnapshotPolicyResource() resource.Resource {
+	return &InstanceSnapshotPolicyResource{}
+}
+
+func (r InstanceSnapshotPolicyResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_instance_snapshot_policy", req.ProviderTypeName)
+}
+
+func (r InstanceSnapshotPolicyResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"instance": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+
+			"name_prefix": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+					stringvalidator.RegexMatches(snapshotNamePrefixRegexp, "must not contain a slash"),
+				},
+			},
+
+			"keep_last": schema.Int64Attribute{
+				Required: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+
+			"stateful": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+
+			"trigger": schema.StringAttribute{
+				Optional: true,
+			},
+
+			"project": schema.StringAttribute{
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+
+			"remote": schema.StringAttribute{
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+
+			// Computed.
+
+			"snapshots": schema.ListAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+			}),
+		},
+	}
+}
+
+func (r *InstanceSnapshotPolicyResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	data := req.ProviderData
+	if data == nil {
+		return
+	}
+
+	provider, ok := data.(*provider_config.IncusProviderConfig)
+	if !ok {
+		resp.Diagnostics.Append(errors.NewProviderDataTypeError(req.ProviderData))
+		return
+	}
+
+	r.provider = provider
+}
+
+func (r *InstanceSnapshotPolicyResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// If resource is being created or destroyed, req.State or req.Plan will be null.
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var state InstanceSnapshotPolicyModel
+	var plan InstanceSnapshotPolicyModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+
+	diags = req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The retained snapshots change if a new snapshot is taken or the
+	// number of retained snapshots changes.
+	if snapshotPolicyTriggered(plan, state) || !plan.KeepLast.Equal(state.KeepLast) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("snapshots"), types.ListUnknown(types.StringType))...)
+	}
+}
+
+func (r InstanceSnapshotPolicyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan InstanceSnapshotPolicyModel
+
+	// Fetch resource model from Terraform plan.
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, 0)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := utils.ContextWithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	remote := plan.Remote.ValueString()
+	project := plan.Project.ValueString()
+	server, err := r.provider.InstanceServer(remote, project, "")
+	if err != nil {
+		resp.Diagnostics.Append(errors.NewInstanceServerError(err))
+		return
+	}
+
+	diags = applySnapshotPolicy(ctx, server, plan, true)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Update Terraform state.
+	diags = r.SyncState(ctx, &resp.State, server, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r InstanceSnapshotPolicyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state InstanceSnapshotPolicyModel
+
+	// Fetch resource model from Terraform state.
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	remote := state.Remote.ValueString()
+	project := state.Project.ValueString()
+	server, err := r.provider.InstanceServer(remote, project, "")
+	if err != nil {
+		resp.Diagnostics.Append(errors.NewInstanceServerError(err))
+		return
+	}
+
+	// Update Terraform state.
+	diags = r.SyncState(ctx, &resp.State, server, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r InstanceSnapshotPolicyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan InstanceSnapshotPolicyModel
+	var state InstanceSnapshotPolicyModel
+
+	// Fetch resource model from Terraform plan.
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, 0)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := utils.ContextWithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	remote := plan.Remote.ValueString()
+	project := plan.Project.ValueString()
+	server, err := r.provider.InstanceServer(remote, project, "")
+	if err != nil {
+		resp.Diagnostics.Append(errors.NewInstanceServerError(err))
+		return
+	}
+
+	diags = applySnapshotPolicy(ctx, server, plan, snapshotPolicyTriggered(plan, state))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Update Terraform state.
+	diags = r.SyncState(ctx, &resp.State, server, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r InstanceSnapshotPolicyResource) Delete(_ context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) {
+	// The retained snapshots are kept, as they may still be needed
+	// for a restore. Removing the policy only removes it from the state.
+}
+
+// SyncState fetches the snapshots retained by the policy and updates the
+// provided model. It then applies this updated model as the new state in
+// Terraform.
+func (r InstanceSnapshotPolicyResource) SyncState(ctx context.Context, tfState *tfsdk.State, server incus.InstanceServer, m InstanceSnapshotPolicyModel) diag.Diagnostics {
+	var respDiags diag.Diagnostics
+
+	instanceName := m.Instance.ValueString()
+	snapshots, err := policySnapshots(server, instanceName, m.NamePrefix.ValueString())
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			tfState.RemoveResource(ctx)
+			return nil
+		}
+
+		respDiags.AddError(fmt.Sprintf("Failed to retrieve snapshots of instance %q", instanceName), err.Error())
+		return respDiags
+	}
+
+	names := make([]string, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		names = append(names, snapshot.Name)
+	}
+
+	snapshotList, diags := types.ListValueFrom(ctx, types.StringType, names)
+	respDiags.Append(diags...)
+	if respDiags.HasError() {
+		return respDiags
+	}
+
+	m.Snapshots = snapshotList
+
+	return tfState.Set(ctx, &m)
+}
+
+// snapshotPolicyTriggered returns true if a new snapshot has to be taken.
+// Without a trigger, a snapshot is taken on each apply.
+func snapshotPolicyTriggered(plan InstanceSnapshotPolicyModel, state InstanceSnapshotPolicyModel) bool {
+	return plan.Trigger.IsNull() || !plan.Trigger.Equal(state.Trigger)
+}
+
+// applySnapshotPolicy optionally takes a new snapshot of the instance and
+// then removes all snapshots matching the policy's name prefix except for
+// the newest "keep_last" ones.
+func applySnapshotPolicy(ctx context.Context, server incus.InstanceServer, m InstanceSnapshotPolicyModel, takeSnapshot bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	instanceName := m.Instance.ValueString()
+	namePrefix := m.NamePrefix.ValueString()
+
+	snapshots, err := policySnapshots(server, instanceName, namePrefix)
+	if err != nil {
+		diags.AddError(fmt.Sprintf("Failed to retrieve snapshots of instance %q", instanceName), err.Error())
+		return diags
+	}
+
+	if takeSnapshot {
+		snapshotReq := api.InstanceSnapshotsPost{
+			Name:     policySnapshotName(namePrefix, snapshots, time.Now()),
+			Stateful: m.Stateful.ValueBool(),
+		}
+
+		err := createInstanceSnapshot(ctx, server, instanceName, snapshotReq)
+		if err != nil {
+			diags.AddError(fmt.Sprintf("Failed to create snapshot %q for instance %q", snapshotReq.Name, instanceName), err.Error())
+			return diags
+		}
+
+		snapshots, err = policySnapshots(server, instanceName, namePrefix)
+		if err != nil {
+			diags.AddError(fmt.Sprintf("Failed to retrieve snapshots of instance %q", instanceName), err.Error())
+			return diags
+		}
+	}
+
+	keepLast := int(m.KeepLast.ValueInt64())
+	if len(snapshots) <= keepLast {
+		return nil
+	}
+
+	// Snapshots are sorted from oldest to newest.
+	for _, snapshot := range snapshots[:len(snapshots)-keepLast] {
+		op, err := server.DeleteInstanceSnapshot(instanceName, snapshot.Name)
+		if err == nil {
+			err = op.WaitContext(ctx)
+		}
+
+		if err != nil && !errors.IsNotFoundError(err) {
+			diags.AddError(fmt.Sprintf("Failed to remove snapshot %q for instance %q", snapshot.Name, instanceName), err.Error())
+			return diags
+		}
+	}
+
+	return nil
+}
+
+// policySnapshots returns the snapshots of the instance that were created
+// by the policy with the given name prefix, sorted from oldest to newest.
+func policySnapshots(server incus.InstanceServer, instanceName string, namePrefix string) ([]api.InstanceSnapshot, error) {
+	allSnapshots, err := server.GetInstanceSnapshots(instanceName)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots := make([]api.InstanceSnapshot, 0, len(allSnapshots))
+	for _, snapshot := range allSnapshots {
+		if isPolicySnapshotName(namePrefix, snapshot.Name) {
+			snapshots = append(snapshots, snapshot)
+		}
+	}
+
+	slices.SortStableFunc(snapshots, func(a api.InstanceSnapshot, b api.InstanceSnapshot) int {
+		c := a.CreatedAt.Compare(b.CreatedAt)
+		if c == 0 {
+			return strings.Compare(a.Name, b.Name)
+		}
+
+		return c
+	})
+
+	return snapshots, nil
+}
+
+// isPolicySnapshotName returns whether the snapshot name was generated by
+// policySnapshotName for the given prefix. Other snapshots that merely
+// share the prefix are not managed by the policy.
+func isPolicySnapshotName(namePrefix string, name string) bool {
+	suffix, ok := strings.CutPrefix(name, namePrefix)
+	return ok && policySnapshotSuffixRegexp.MatchString(suffix)
+}
+
+// policySnapshotName returns the name of a new snapshot, which consists of
+// the name prefix and the current time. A numeric suffix is added if a
+// snapshot with the same name already exists.
+func policySnapshotName(namePrefix string, snapshots []api.InstanceSnapshot, now time.Time) string {
+	baseName := namePrefix + now.UTC().Format("20060102-150405")
+
+	exists := func(name string) bool {
+		return slices.ContainsFunc(snapshots, func(s api.InstanceSnapshot) bool {
+			return s.Name == name
+		})
+	}
+
+	name := baseName
+	for i := 1; exists(name); i++ {
+		name = fmt.Sprintf("%s-%d", baseName, i)
+	}
+
+	return name
+}
@@ -0,0 +1,72 @@
+package instance
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lxc/incus/v7/shared/api"
+)
+
+func TestInstanceSnapshotPolicy_isPolicySnapshotName(t *testing.T) {
+	tests := []struct {
+		name         string
+		snapshotName string
+		expected     bool
+	}{
+		{
+			name:         "policy snapshot",
+			snapshotName: "auto-20250102-030405",
+			expected:     true,
+		},
+		{
+			name:         "policy snapshot with suffix",
+			snapshotName: "auto-20250102-030405-2",
+			expected:     true,
+		},
+		{
+			name:         "foreign snapshot sharing the prefix",
+			snapshotName: "auto-before-upgrade",
+			expected:     false,
+		},
+		{
+			name:         "foreign snapshot with a timestamp",
+			snapshotName: "auto-20250102-030405-manual",
+			expected:     false,
+		},
+		{
+			name:         "different prefix",
+			snapshotName: "snap-20250102-030405",
+			expected:     false,
+		},
+		{
+			name:         "prefix only",
+			snapshotName: "auto-",
+			expected:     false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := isPolicySnapshotName("auto-", test.snapshotName)
+			if got != test.expected {
+				t.Fatalf("isPolicySnapshotName(%q) = %t, expected %t", test.snapshotName, got, test.expected)
+			}
+		})
+	}
+}
+
+func TestInstanceSnapshotPolicy_policySnapshotName(t *testing.T) {
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	snapshots := []api.InstanceSnapshot{
+		{Name: "auto-20250102-030405"},
+	}
+
+	name := policySnapshotName("auto-", snapshots, now)
+	if name != "auto-20250102-030405-1" {
+		t.Fatalf("Unexpected snapshot name %q", name)
+	}
+
+	if !isPolicySnapshotName("auto-", name) {
+		t.Fatalf("Snapshot name %q is not recognized as a policy snapshot", name)
+	}
+}
@@ -0,0 +1,104 @@
+package instance_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	petname "github.com/dustinkirkland/golang-petname"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/lxc/terraform-provider-incus/internal/acctest"
+)
+
+func TestAccInstanceSnapshotPolicy_keepLast(t *testing.T) {
+	instanceName := petname.Generate(2, "-")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccInstanceSnapshotPolicy_basic(instanceName, 2, "1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_instance_snapshot_policy.policy1", "instance", instanceName),
+					resource.TestCheckResourceAttr("incus_instance_snapshot_policy.policy1", "snapshots.#", "1"),
+					resource.TestMatchResourceAttr("incus_instance_snapshot_policy.policy1", "snapshots.0", regexp.MustCompile(`^release-\d{8}-\d{6}(-\d+)?$`)),
+				),
+			},
+			{
+				Config: testAccInstanceSnapshotPolicy_basic(instanceName, 2, "2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_instance_snapshot_policy.policy1", "snapshots.#", "2"),
+				),
+			},
+			{
+				// The oldest snapshot is removed.
+				Config: testAccInstanceSnapshotPolicy_basic(instanceName, 2, "3"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_instance_snapshot_policy.policy1", "snapshots.#", "2"),
+				),
+			},
+			{
+				// Lowering keep_last removes snapshots without taking a new one.
+				Config: testAccInstanceSnapshotPolicy_basic(instanceName, 1, "3"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_instance_snapshot_policy.policy1", "keep_last", "1"),
+					resource.TestCheckResourceAttr("incus_instance_snapshot_policy.policy1", "snapshots.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccInstanceSnapshotPolicy_ignoresOtherSnapshots(t *testing.T) {
+	instanceName := petname.Generate(2, "-")
+	snapshotName := petname.Generate(2, "-")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccInstanceSnapshotPolicy_basic(instanceName, 1, "1") + testAccInstanceSnapshotPolicy_snapshot(snapshotName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_instance_snapshot.snapshot1", "name", snapshotName),
+					resource.TestCheckResourceAttr("incus_instance_snapshot_policy.policy1", "snapshots.#", "1"),
+				),
+			},
+			{
+				Config: testAccInstanceSnapshotPolicy_basic(instanceName, 1, "2") + testAccInstanceSnapshotPolicy_snapshot(snapshotName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_instance_snapshot.snapshot1", "name", snapshotName),
+					resource.TestCheckResourceAttr("incus_instance_snapshot_policy.policy1", "snapshots.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func testAccInstanceSnapshotPolicy_basic(instanceName string, keepLast int, trigger string) string {
+	return fmt.Sprintf(`
+resource "incus_instance" "instance1" {
+  name    = "%s"
+  image   = "%s"
+  running = false
+}
+
+resource "incus_instance_snapshot_policy" "policy1" {
+  instance    = incus_instance.instance1.name
+  name_prefix = "release-"
+  keep_last   = %d
+  trigger     = "%s"
+}
+	`, instanceName, acctest.TestImage, keepLast, trigger)
+}
+
+func testAccInstanceSnapshotPolicy_snapshot(snapshotName string) string {
+	return fmt.Sprintf(`
+resource "incus_instance_snapshot" "snapshot1" {
+  name     = "%s"
+  instance = incus_instance.instance1.name
+}
+	`, snapshotName)
+}
@@ -6,6 +6,7 @@ import (
 
 	petname "github.com/dustinkirkland/golang-petname"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 
 	"github.com/lxc/terraform-provider-incus/internal/acctest"
 )
@@ -118,6 +119,115 @@ func TestAccInstanceSnapshot_project(t *testing.T) {
 	})
 }
 
+func TestAccInstanceSnapshot_update(t *testing.T) {
+	instanceName := petname.Generate(2, "-")
+	snapshotName := petname.Generate(2, "-")
+	newSnapshotName := petname.Generate(2, "-")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccInstanceSnapshot_update(instanceName, snapshotName, "Before upgrade", "2099-01-01T00:00:00Z"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_instance_snapshot.snapshot1", "name", snapshotName),
+					resource.TestCheckResourceAttr("incus_instance_snapshot.snapshot1", "description", "Before upgrade"),
+					resource.TestCheckResourceAttr("incus_instance_snapshot.snapshot1", "expires_at", "2099-01-01T00:00:00Z"),
+				),
+			},
+			{
+				Config: testAccInstanceSnapshot_update(instanceName, snapshotName, "Keep longer", "2099-06-01T12:00:00Z"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("incus_instance_snapshot.snapshot1", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_instance_snapshot.snapshot1", "name", snapshotName),
+					resource.TestCheckResourceAttr("incus_instance_snapshot.snapshot1", "description", "Keep longer"),
+					resource.TestCheckResourceAttr("incus_instance_snapshot.snapshot1", "expires_at", "2099-06-01T12:00:00Z"),
+				),
+			},
+			{
+				// Renaming the snapshot does not recreate it.
+				Config: testAccInstanceSnapshot_update(instanceName, newSnapshotName, "Keep longer", "2099-06-01T12:00:00Z"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("incus_instance_snapshot.snapshot1", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_instance_snapshot.snapshot1", "name", newSnapshotName),
+					resource.TestCheckResourceAttr("incus_instance_snapshot.snapshot1", "description", "Keep longer"),
+				),
+			},
+			{
+				// Removing expires_at clears the expiry.
+				Config: testAccInstanceSnapshot_description(instanceName, newSnapshotName, "Keep longer"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("incus_instance_snapshot.snapshot1", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_instance_snapshot.snapshot1", "name", newSnapshotName),
+					resource.TestCheckNoResourceAttr("incus_instance_snapshot.snapshot1", "expires_at"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccInstanceSnapshot_inheritExpiry(t *testing.T) {
+	instanceName := petname.Generate(2, "-")
+	snapshotName := petname.Generate(2, "-")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccInstanceSnapshot_inheritExpiry(instanceName, snapshotName, "Inherited"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("incus_instance_snapshot.snapshot1", "expires_at"),
+				),
+			},
+			{
+				// The inherited expiry is kept on update.
+				Config: testAccInstanceSnapshot_inheritExpiry(instanceName, snapshotName, "Still inherited"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_instance_snapshot.snapshot1", "description", "Still inherited"),
+					resource.TestCheckResourceAttrSet("incus_instance_snapshot.snapshot1", "expires_at"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccInstanceSnapshot_importBasic(t *testing.T) {
+	instanceName := petname.Generate(2, "-")
+	snapshotName := petname.Generate(2, "-")
+	resourceName := "incus_instance_snapshot.snapshot1"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccInstanceSnapshot_update(instanceName, snapshotName, "Imported", "2099-01-01T00:00:00Z"),
+			},
+			{
+				ResourceName:                         resourceName,
+				ImportStateId:                        fmt.Sprintf("/%s/%s", instanceName, snapshotName),
+				ImportStateVerifyIdentifierAttribute: "name",
+				ImportStateVerify:                    true,
+				ImportState:                          true,
+			},
+		},
+	})
+}
+
 func testAccInstanceSnapshot_basic(cName, sName string, stateful bool) string {
 	return fmt.Sprintf(`
 resource "incus_instance" "instance1" {
@@ -194,3 +304,53 @@ resource "incus_instance_snapshot" "snapshot1" {
 }
 	`, project, instance, acctest.TestImage, snapshot)
 }
+
+func testAccInstanceSnapshot_update(instance, snapshot, description, expiresAt string) string {
+	return fmt.Sprintf(`
+resource "incus_instance" "instance1" {
+  name  = "%s"
+  image = "%s"
+}
+
+resource "incus_instance_snapshot" "snapshot1" {
+  name        = "%s"
+  instance    = incus_instance.instance1.name
+  description = "%s"
+  expires_at  = "%s"
+}
+	`, instance, acctest.TestImage, snapshot, description, expiresAt)
+}
+
+func testAccInstanceSnapshot_description(instance, snapshot, description string) string {
+	return fmt.Sprintf(`
+resource "incus_instance" "instance1" {
+  name  = "%s"
+  image = "%s"
+}
+
+resource "incus_instance_snapshot" "snapshot1" {
+  name        = "%s"
+  instance    = incus_instance.instance1.name
+  description = "%s"
+}
+	`, instance, acctest.TestImage, snapshot, description)
+}
+
+func testAccInstanceSnapshot_inheritExpiry(instance, snapshot, description string) string {
+	return fmt.Sprintf(`
+resource "incus_instance" "instance1" {
+  name  = "%s"
+  image = "%s"
+
+  config = {
+    "snapshots.expiry" = "1d"
+  }
+}
+
+resource "incus_instance_snapshot" "snapshot1" {
+  name        = "%s"
+  instance    = incus_instance.instance1.name
+  description = "%s"
+}
+	`, instance, acctest.TestImage, snapshot, description)
+}
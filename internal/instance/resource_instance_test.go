@@ -2,6 +2,7 @@ package instance_test
 
 import (
 	"fmt"
+	"os"
 	"path/filepath"
 	"regexp"
 	"testing"
@@ -9,6 +10,7 @@ import (
 	petname "github.com/dustinkirkland/golang-petname"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/statecheck"
 	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
 
@@ -268,6 +270,124 @@ func TestAccInstance_execTimeout(t *testing.T) {
 	})
 }
 
+func TestAccInstance_execOutput(t *testing.T) {
+	instanceName := petname.Generate(2, "-")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccInstance_execOutput(instanceName, "v1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_instance.instance1", "exec.output.stdout", "hello\n"),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "exec.output.stderr", "oops\n"),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "exec.output.exit_code", "0"),
+				),
+			},
+			{
+				// The exec entry does not run again, so its output is kept.
+				Config: testAccInstance_execOutput(instanceName, "v2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_instance.instance1", "description", "v2"),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "exec.output.stdout", "hello\n"),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "exec.output.exit_code", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccInstance_execAllowedExitCodes(t *testing.T) {
+	instanceName := petname.Generate(2, "-")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccInstance_execAllowedExitCodes(instanceName, "3"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_instance.instance1", "exec.check.exit_code", "3"),
+				),
+			},
+			{
+				Config:      testAccInstance_execAllowedExitCodes(instanceName, "4"),
+				ExpectError: regexp.MustCompile("exec returned non-zero status 4"),
+			},
+		},
+	})
+}
+
+func TestAccInstance_execFailsOnCreate(t *testing.T) {
+	instanceName := petname.Generate(2, "-")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccInstance_execOnChangeFail(instanceName, "v1"),
+				ExpectError: regexp.MustCompile("Failed to execute command"),
+			},
+			{
+				// The failed instance is kept in state and replaced.
+				Config: testAccInstance_execOutput(instanceName, "v2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_instance.instance1", "description", "v2"),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "exec.%", "1"),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "exec.output.stdout", "hello\n"),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "exec.output.exit_code", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccInstance_fileSourceDir(t *testing.T) {
+	instanceName := petname.Generate(2, "-")
+	sourceDir := t.TempDir()
+
+	writeFile := func(name string, content string) {
+		err := os.MkdirAll(filepath.Dir(filepath.Join(sourceDir, name)), 0o755)
+		if err == nil {
+			err = os.WriteFile(filepath.Join(sourceDir, name), []byte(content), 0o644)
+		}
+
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					writeFile("a.txt", "a")
+					writeFile("sub/b.txt", "b")
+				},
+				Config: testAccInstance_fileSourceDir(instanceName, sourceDir, "test -f /opt/app/a.txt && test -f /opt/app/sub/b.txt"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_instance.instance1", "file.#", "1"),
+					resource.TestCheckResourceAttrSet("incus_instance.instance1", "file.0.source_dir_hash"),
+				),
+			},
+			{
+				PreConfig: func() {
+					if err := os.Remove(filepath.Join(sourceDir, "a.txt")); err != nil {
+						t.Fatal(err)
+					}
+
+					writeFile("sub/c.txt", "c")
+				},
+				Config: testAccInstance_fileSourceDir(instanceName, sourceDir, "test ! -e /opt/app/a.txt && test -f /opt/app/sub/c.txt"),
+			},
+		},
+	})
+}
+
 func TestAccInstance_waitForFailureKeepsStateInProject(t *testing.T) {
 	projectName := petname.Generate(2, "-")
 	instanceName := petname.Generate(2, "-")
@@ -295,6 +415,47 @@ func TestAccInstance_waitForFailureKeepsStateInProject(t *testing.T) {
 	})
 }
 
+func TestAccInstance_timeouts(t *testing.T) {
+	instanceName := petname.Generate(2, "-")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccInstance_timeouts(instanceName, "10m", "1s"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_instance.instance1", "name", instanceName),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "status", "Running"),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "timeouts.create", "10m"),
+				),
+			},
+			{
+				Config: testAccInstance_timeouts(instanceName, "15m", "1s"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_instance.instance1", "status", "Running"),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "timeouts.create", "15m"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccInstance_timeoutsCreateExceeded(t *testing.T) {
+	instanceName := petname.Generate(2, "-")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccInstance_timeouts(instanceName, "5s", "30s"),
+				ExpectError: regexp.MustCompile("(context deadline exceeded|timeout while waiting)"),
+			},
+		},
+	})
+}
+
 func TestAccInstance_container(t *testing.T) {
 	instanceName := petname.Generate(2, "-")
 
@@ -483,6 +644,146 @@ func TestAccInstance_restartVirtualMachine(t *testing.T) {
 	})
 }
 
+func TestAccInstance_desiredState(t *testing.T) {
+	instanceName := petname.Generate(2, "-")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccInstance_desiredState(instanceName, "frozen"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_instance.instance1", "name", instanceName),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "status", "Frozen"),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "desired_state", "frozen"),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "running", "false"),
+				),
+			},
+			{
+				Config: testAccInstance_desiredState(instanceName, "running"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_instance.instance1", "status", "Running"),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "desired_state", "running"),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "running", "true"),
+				),
+			},
+			{
+				Config: testAccInstance_desiredState(instanceName, "stopped"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_instance.instance1", "status", "Stopped"),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "desired_state", "stopped"),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "running", "false"),
+				),
+			},
+			{
+				Config: testAccInstance_desiredState(instanceName, "frozen"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_instance.instance1", "status", "Frozen"),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "desired_state", "frozen"),
+				),
+			},
+			{
+				// Switching back to "running" thaws the instance.
+				Config: testAccInstance_started(instanceName, "container"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_instance.instance1", "status", "Running"),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "desired_state", "running"),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "running", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccInstance_desiredStateConflictsWithRunning(t *testing.T) {
+	instanceName := petname.Generate(2, "-")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccInstance_desiredStateWithRunning(instanceName),
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+			},
+		},
+	})
+}
+
+func TestAccInstance_statefulStop(t *testing.T) {
+	instanceName := petname.Generate(2, "-")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			acctest.PreCheck(t)
+			acctest.PreCheckVirtualization(t)
+		},
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccInstance_statefulStop(instanceName, "running"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_instance.instance1", "name", instanceName),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "status", "Running"),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "stateful_stop", "true"),
+				),
+			},
+			{
+				Config: testAccInstance_statefulStop(instanceName, "stopped"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_instance.instance1", "status", "Stopped"),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "desired_state", "stopped"),
+				),
+			},
+			{
+				Config: testAccInstance_statefulStop(instanceName, "running"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_instance.instance1", "status", "Running"),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "desired_state", "running"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccInstance_restoreSnapshot(t *testing.T) {
+	instanceName := petname.Generate(2, "-")
+	snapshotName := petname.Generate(2, "-")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccInstance_restoreSnapshot(instanceName, snapshotName, "", ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_instance.instance1", "name", instanceName),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "status", "Running"),
+					resource.TestCheckNoResourceAttr("incus_instance.instance1", "restore_snapshot"),
+					resource.TestCheckResourceAttr("incus_instance_snapshot.snapshot1", "name", snapshotName),
+				),
+			},
+			{
+				Config: testAccInstance_restoreSnapshot(instanceName, snapshotName, snapshotName, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_instance.instance1", "status", "Running"),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "restore_snapshot", snapshotName),
+				),
+			},
+			{
+				// Changing the trigger restores the same snapshot again.
+				Config: testAccInstance_restoreSnapshot(instanceName, snapshotName, snapshotName, "2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_instance.instance1", "status", "Running"),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "restore_snapshot", snapshotName),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "restore_trigger", "2"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccInstance_remoteImage(t *testing.T) {
 	instanceName := petname.Generate(2, "-")
 
@@ -851,6 +1152,30 @@ func TestAccInstance_fileUploadContent_VM(t *testing.T) {
 	})
 }
 
+func TestAccInstance_fileCreateDirectories_VM(t *testing.T) {
+	instanceName := petname.Generate(2, "-")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			acctest.PreCheck(t)
+			acctest.PreCheckVirtualization(t)
+		},
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccInstance_fileCreateDirectories_VM(instanceName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_instance.instance1", "type", "virtual-machine"),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "file.0.target_path", "/opt/app/conf/settings.txt"),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "file.0.create_directories", "true"),
+					// The verify command fails unless the nested directories exist with the requested mode.
+					resource.TestCheckResourceAttr("incus_instance.instance1", "exec.verify.stdout", "Hello from VM!\n"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccInstance_fileUploadSource(t *testing.T) {
 	instanceName := petname.Generate(2, "-")
 
@@ -1002,6 +1327,85 @@ func TestAccInstance_target(t *testing.T) {
 	})
 }
 
+func TestAccInstance_targetMigrate(t *testing.T) {
+	instanceName := petname.Generate(2, "-")
+
+	clusterMemberNames := make(map[string]struct{}, 10)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			acctest.PreCheck(t)
+			acctest.PreCheckClustering(t)
+		},
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccInstance_targetMember(instanceName, "0"),
+				Check: resource.ComposeTestCheckFunc(
+					acctest.TestCheckGetClusterMemberNames(t, "data.incus_cluster.test", clusterMemberNames),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "name", instanceName),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "status", "Running"),
+					resource.TestCheckResourceAttrPair("incus_instance.instance1", "target", "terraform_data.member", "output"),
+				),
+			},
+			{
+				// Changing the target migrates the instance in place.
+				Config: testAccInstance_targetMember(instanceName, "length(local.member_names) - 1"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("incus_instance.instance1", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_instance.instance1", "name", instanceName),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "status", "Running"),
+					resource.TestCheckResourceAttrPair("incus_instance.instance1", "target", "terraform_data.member", "output"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccInstance_targetMigrateFrozen(t *testing.T) {
+	instanceName := petname.Generate(2, "-")
+
+	clusterMemberNames := make(map[string]struct{}, 10)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			acctest.PreCheck(t)
+			acctest.PreCheckClustering(t)
+		},
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccInstance_targetMemberFrozen(instanceName, "0"),
+				Check: resource.ComposeTestCheckFunc(
+					acctest.TestCheckGetClusterMemberNames(t, "data.incus_cluster.test", clusterMemberNames),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "name", instanceName),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "status", "Frozen"),
+					resource.TestCheckResourceAttrPair("incus_instance.instance1", "target", "terraform_data.member", "output"),
+				),
+			},
+			{
+				// A frozen instance is thawed, moved and frozen again.
+				Config: testAccInstance_targetMemberFrozen(instanceName, "length(local.member_names) - 1"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("incus_instance.instance1", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_instance.instance1", "name", instanceName),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "status", "Frozen"),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "desired_state", "frozen"),
+					resource.TestCheckResourceAttrPair("incus_instance.instance1", "target", "terraform_data.member", "output"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccInstance_createProject(t *testing.T) {
 	instanceName := petname.Generate(2, "-")
 	projectName := petname.Name()
@@ -1195,6 +1599,42 @@ func TestAccInstance_sourceInstanceWithSnapshot(t *testing.T) {
 	})
 }
 
+func TestAccInstance_sourceInstanceRefresh(t *testing.T) {
+	projectName := petname.Name()
+	sourceInstanceName := petname.Generate(2, "-")
+	instanceName := petname.Generate(2, "-")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccInstance_sourceInstanceRefresh(projectName, sourceInstanceName, instanceName, "1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_instance.instance2", "name", instanceName),
+					resource.TestCheckResourceAttr("incus_instance.instance2", "status", "Running"),
+					resource.TestCheckResourceAttr("incus_instance.instance2", "source_instance.refresh", "true"),
+					resource.TestCheckResourceAttr("incus_instance.instance2", "source_instance.refresh_trigger", "1"),
+				),
+			},
+			{
+				// Changing the trigger refreshes the copy in place.
+				Config: testAccInstance_sourceInstanceRefresh(projectName, sourceInstanceName, instanceName, "2"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("incus_instance.instance2", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_instance.instance2", "name", instanceName),
+					resource.TestCheckResourceAttr("incus_instance.instance2", "status", "Running"),
+					resource.TestCheckResourceAttr("incus_instance.instance2", "source_instance.refresh_trigger", "2"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccInstance_sourceFile(t *testing.T) {
 	tmpDir := t.TempDir()
 	backupFile := filepath.Join(tmpDir, "backup.tar.gz")
@@ -1388,31 +1828,133 @@ func TestAccInstance_waitForCloudInitContainer(t *testing.T) {
 	})
 }
 
-func TestAccInstance_waitForCloudInitVM(t *testing.T) {
+func TestAccInstance_waitForCloudInitVM(t *testing.T) {
+	instanceName := petname.Generate(2, "-")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			acctest.PreCheck(t)
+			acctest.PreCheckVirtualization(t)
+		},
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccInstance_waitForCloudInit(instanceName, "virtual-machine"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_instance.instance1", "name", instanceName),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "type", "virtual-machine"),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "status", "Running"),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "wait_for.0.type", "cloud-init"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccInstance_waitForCloudInitFailureLogs(t *testing.T) {
+	instanceName := petname.Generate(2, "-")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// The output of the failing runcmd is part of the error.
+				Config:      testAccInstance_waitForCloudInitFailure(instanceName),
+				ExpectError: regexp.MustCompile(`(?s)cloud-init-output\.log.*terraform-provider-incus-broken`),
+			},
+		},
+	})
+}
+
+func TestAccInstance_waitForIPv4(t *testing.T) {
+	networkName := petname.Generate(1, "-")
+	instanceName := petname.Generate(2, "-")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccInstance_waitForIPv4(networkName, instanceName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_instance.instance1", "name", instanceName),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "status", "Running"),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "wait_for.0.type", "ipv4"),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "wait_for.0.nic", "eth0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccInstance_waitForIPv6(t *testing.T) {
+	networkName := petname.Generate(1, "-")
+	instanceName := petname.Generate(2, "-")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccInstance_waitForIPv6(networkName, instanceName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_instance.instance1", "name", instanceName),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "status", "Running"),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "wait_for.0.type", "ipv6"),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "wait_for.0.nic", "eth0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccInstance_waitForIPv4AndIPv6(t *testing.T) {
+	networkName := petname.Generate(1, "-")
+	instanceName := petname.Generate(2, "-")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccInstance_waitForIPv4AndIPv6(networkName, instanceName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_instance.instance1", "name", instanceName),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "status", "Running"),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "wait_for.0.type", "ipv4"),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "wait_for.0.nic", "eth0"),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "wait_for.1.type", "ipv6"),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "wait_for.1.nic", "eth0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccInstance_waitForExec(t *testing.T) {
 	instanceName := petname.Generate(2, "-")
 
 	resource.Test(t, resource.TestCase{
-		PreCheck: func() {
-			acctest.PreCheck(t)
-			acctest.PreCheckVirtualization(t)
-		},
+		PreCheck:                 func() { acctest.PreCheck(t) },
 		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccInstance_waitForCloudInit(instanceName, "virtual-machine"),
+				Config: testAccInstance_waitForExec(instanceName),
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr("incus_instance.instance1", "name", instanceName),
-					resource.TestCheckResourceAttr("incus_instance.instance1", "type", "virtual-machine"),
 					resource.TestCheckResourceAttr("incus_instance.instance1", "status", "Running"),
-					resource.TestCheckResourceAttr("incus_instance.instance1", "wait_for.0.type", "cloud-init"),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "wait_for.0.type", "exec"),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "wait_for.0.command.#", "3"),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "wait_for.0.interval", "1s"),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "wait_for.0.timeout", "1m"),
 				),
 			},
 		},
 	})
 }
 
-func TestAccInstance_waitForIPv4(t *testing.T) {
-	networkName := petname.Generate(1, "-")
+func TestAccInstance_waitForFile(t *testing.T) {
 	instanceName := petname.Generate(2, "-")
 
 	resource.Test(t, resource.TestCase{
@@ -1420,20 +1962,19 @@ func TestAccInstance_waitForIPv4(t *testing.T) {
 		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccInstance_waitForIPv4(networkName, instanceName),
+				Config: testAccInstance_waitForFile(instanceName),
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr("incus_instance.instance1", "name", instanceName),
 					resource.TestCheckResourceAttr("incus_instance.instance1", "status", "Running"),
-					resource.TestCheckResourceAttr("incus_instance.instance1", "wait_for.0.type", "ipv4"),
-					resource.TestCheckResourceAttr("incus_instance.instance1", "wait_for.0.nic", "eth0"),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "wait_for.0.type", "file"),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "wait_for.0.path", "/etc/os-release"),
 				),
 			},
 		},
 	})
 }
 
-func TestAccInstance_waitForIPv6(t *testing.T) {
-	networkName := petname.Generate(1, "-")
+func TestAccInstance_waitForPortTimeout(t *testing.T) {
 	instanceName := petname.Generate(2, "-")
 
 	resource.Test(t, resource.TestCase{
@@ -1441,20 +1982,15 @@ func TestAccInstance_waitForIPv6(t *testing.T) {
 		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccInstance_waitForIPv6(networkName, instanceName),
-				Check: resource.ComposeTestCheckFunc(
-					resource.TestCheckResourceAttr("incus_instance.instance1", "name", instanceName),
-					resource.TestCheckResourceAttr("incus_instance.instance1", "status", "Running"),
-					resource.TestCheckResourceAttr("incus_instance.instance1", "wait_for.0.type", "ipv6"),
-					resource.TestCheckResourceAttr("incus_instance.instance1", "wait_for.0.nic", "eth0"),
-				),
+				// Nothing listens on the port, so the condition times out.
+				Config:      testAccInstance_waitForPort(instanceName, 8080, "10s"),
+				ExpectError: regexp.MustCompile(`Failed to wait for port 8080 to listen`),
 			},
 		},
 	})
 }
 
-func TestAccInstance_waitForIPv4AndIPv6(t *testing.T) {
-	networkName := petname.Generate(1, "-")
+func TestAccInstance_waitForConditionInvalid(t *testing.T) {
 	instanceName := petname.Generate(2, "-")
 
 	resource.Test(t, resource.TestCase{
@@ -1462,15 +1998,8 @@ func TestAccInstance_waitForIPv4AndIPv6(t *testing.T) {
 		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccInstance_waitForIPv4AndIPv6(networkName, instanceName),
-				Check: resource.ComposeTestCheckFunc(
-					resource.TestCheckResourceAttr("incus_instance.instance1", "name", instanceName),
-					resource.TestCheckResourceAttr("incus_instance.instance1", "status", "Running"),
-					resource.TestCheckResourceAttr("incus_instance.instance1", "wait_for.0.type", "ipv4"),
-					resource.TestCheckResourceAttr("incus_instance.instance1", "wait_for.0.nic", "eth0"),
-					resource.TestCheckResourceAttr("incus_instance.instance1", "wait_for.1.type", "ipv6"),
-					resource.TestCheckResourceAttr("incus_instance.instance1", "wait_for.1.nic", "eth0"),
-				),
+				Config:      testAccInstance_waitForPort(instanceName, 8080, "soon"),
+				ExpectError: regexp.MustCompile(`Invalid "timeout" for wait_for type "port"`),
 			},
 		},
 	})
@@ -2036,6 +2565,39 @@ resource "incus_instance" "instance1" {
 	`, name, acctest.TestImage)
 }
 
+func testAccInstance_fileCreateDirectories_VM(name string) string {
+	return fmt.Sprintf(`
+resource "incus_instance" "instance1" {
+  name  = "%s"
+  image = "%s"
+  type  = "virtual-machine"
+
+  config = {
+    # Alpine images do not support secureboot.
+    "security.secureboot" = false
+  }
+
+  wait_for {
+    type = "agent"
+  }
+
+  file {
+    content            = "Hello from VM!\n"
+    target_path        = "/opt/app/conf/settings.txt"
+    mode               = "0644"
+    directory_mode     = "0750"
+    create_directories = true
+  }
+
+  exec = {
+    "verify" = {
+      command = ["/bin/sh", "-c", "test $(stat -c %%a /opt/app/conf) = 750 && cat /opt/app/conf/settings.txt"]
+    }
+  }
+}
+`, name, acctest.TestImage)
+}
+
 func testAccInstance_fileUploadSource(name string) string {
 	return fmt.Sprintf(`
 resource "incus_instance" "instance1" {
@@ -2149,6 +2711,47 @@ resource "incus_instance" "instance2" {
 	`, name, acctest.TestImage)
 }
 
+func testAccInstance_targetMember(name string, index string) string {
+	return fmt.Sprintf(`
+data "incus_cluster" "test" {}
+
+locals {
+  member_names = [ for k, v in data.incus_cluster.test.members : k ]
+}
+
+resource "terraform_data" "member" {
+  input = element(tolist(local.member_names), %[3]s)
+}
+
+resource "incus_instance" "instance1" {
+  name   = "%[1]s"
+  image  = "%[2]s"
+  target = terraform_data.member.output
+}
+	`, name, acctest.TestImage, index)
+}
+
+func testAccInstance_targetMemberFrozen(name string, index string) string {
+	return fmt.Sprintf(`
+data "incus_cluster" "test" {}
+
+locals {
+  member_names = [ for k, v in data.incus_cluster.test.members : k ]
+}
+
+resource "terraform_data" "member" {
+  input = element(tolist(local.member_names), %[3]s)
+}
+
+resource "incus_instance" "instance1" {
+  name          = "%[1]s"
+  image         = "%[2]s"
+  target        = terraform_data.member.output
+  desired_state = "frozen"
+}
+	`, name, acctest.TestImage, index)
+}
+
 func testAccInstance_project(projectName string, instanceName string) string {
 	return fmt.Sprintf(`
 resource "incus_project" "project1" {
@@ -2230,6 +2833,37 @@ resource "incus_instance" "instance2" {
 	`, projectName, sourceInstanceName, instanceName, acctest.TestImage)
 }
 
+func testAccInstance_sourceInstanceRefresh(projectName, sourceInstanceName string, instanceName string, trigger string) string {
+	return fmt.Sprintf(`
+resource "incus_project" "project1" {
+  name = "%[1]s"
+  config = {
+    "features.images"   = false
+    "features.profiles" = false
+  }
+}
+
+resource "incus_instance" "instance1" {
+  project = incus_project.project1.name
+  name    = "%[2]s"
+  image   = "%[4]s"
+  running = false
+}
+
+resource "incus_instance" "instance2" {
+  project = incus_project.project1.name
+  name    = "%[3]s"
+
+  source_instance = {
+    project         = incus_project.project1.name
+    name            = incus_instance.instance1.name
+    refresh         = true
+    refresh_trigger = "%[5]s"
+  }
+}
+	`, projectName, sourceInstanceName, instanceName, acctest.TestImage, trigger)
+}
+
 func testAccInstance_sourceInstanceWithSnapshot(projectName, sourceInstanceName string, snapshotName string, instanceName string) string {
 	return fmt.Sprintf(`
 resource "incus_project" "project1" {
@@ -2410,6 +3044,23 @@ resource "incus_instance" "instance1" {
 	`, name, instanceType)
 }
 
+func testAccInstance_waitForCloudInitFailure(name string) string {
+	return fmt.Sprintf(`
+resource "incus_instance" "instance1" {
+  name  = "%s"
+  image = "images:ubuntu/24.04/cloud"
+
+  config = {
+    "user.user-data" = "#cloud-config\nruncmd:\n  - echo terraform-provider-incus-broken\n  - exit 1\n"
+  }
+
+  wait_for {
+    type = "cloud-init"
+  }
+}
+	`, name)
+}
+
 func testAccInstance_waitForIPv4(networkName, instanceName string) string {
 	return fmt.Sprintf(`
 resource "incus_network" "network1" {
@@ -2708,6 +3359,26 @@ resource "incus_instance" "instance1" {
 `, instanceName, acctest.TestImage, description, verifyCommand)
 }
 
+func testAccInstance_timeouts(instanceName string, createTimeout string, delay string) string {
+	return fmt.Sprintf(`
+resource "incus_instance" "instance1" {
+  name  = "%s"
+  image = "%s"
+
+  wait_for {
+    type  = "delay"
+    delay = "%s"
+  }
+
+  timeouts {
+    create = "%s"
+    update = "5m"
+    delete = "5m"
+  }
+}
+`, instanceName, acctest.TestImage, delay, createTimeout)
+}
+
 func testAccInstance_execTimeout(instanceName string) string {
 	return fmt.Sprintf(`
 resource "incus_instance" "instance1" {
@@ -2725,6 +3396,61 @@ resource "incus_instance" "instance1" {
 `, instanceName, acctest.TestImage)
 }
 
+func testAccInstance_execOutput(instanceName, description string) string {
+	return fmt.Sprintf(`
+resource "incus_instance" "instance1" {
+  name        = "%s"
+  image       = "%s"
+  description = "%s"
+
+  exec = {
+    "output" = {
+      command = ["/bin/sh", "-c", "echo hello; echo oops >&2"]
+      trigger = "once"
+    }
+  }
+}
+`, instanceName, acctest.TestImage, description)
+}
+
+func testAccInstance_execAllowedExitCodes(instanceName, exitCode string) string {
+	return fmt.Sprintf(`
+resource "incus_instance" "instance1" {
+  name  = "%s"
+  image = "%s"
+
+  exec = {
+    "check" = {
+      command            = ["/bin/sh", "-c", "exit %s"]
+      allowed_exit_codes = [1, 2, 3]
+    }
+  }
+}
+`, instanceName, acctest.TestImage, exitCode)
+}
+
+func testAccInstance_fileSourceDir(instanceName string, sourceDir string, verify string) string {
+	return fmt.Sprintf(`
+resource "incus_instance" "instance1" {
+  name  = "%s"
+  image = "%s"
+
+  file {
+    source_dir         = "%s"
+    target_path        = "/opt/app"
+    create_directories = true
+    delete_removed     = true
+  }
+
+  exec = {
+    "verify" = {
+      command = ["/bin/sh", "-c", %q]
+    }
+  }
+}
+`, instanceName, acctest.TestImage, sourceDir, verify)
+}
+
 func testAccInstance_waitForIPv4AndIPv6(networkName, instanceName string) string {
 	return fmt.Sprintf(`
 resource "incus_network" "network1" {
@@ -2767,3 +3493,116 @@ resource "incus_instance" "instance1" {
 }
 	`, networkName, instanceName, acctest.TestImage)
 }
+
+func testAccInstance_waitForExec(name string) string {
+	return fmt.Sprintf(`
+resource "incus_instance" "instance1" {
+  name  = "%s"
+  image = "%s"
+
+  wait_for {
+    type     = "exec"
+    command  = ["test", "-f", "/etc/os-release"]
+    interval = "1s"
+    timeout  = "1m"
+  }
+}
+	`, name, acctest.TestImage)
+}
+
+func testAccInstance_waitForFile(name string) string {
+	return fmt.Sprintf(`
+resource "incus_instance" "instance1" {
+  name  = "%s"
+  image = "%s"
+
+  wait_for {
+    type = "file"
+    path = "/etc/os-release"
+  }
+}
+	`, name, acctest.TestImage)
+}
+
+func testAccInstance_waitForPort(name string, port int, timeout string) string {
+	return fmt.Sprintf(`
+resource "incus_instance" "instance1" {
+  name  = "%s"
+  image = "%s"
+
+  wait_for {
+    type     = "port"
+    port     = %d
+    timeout  = "%s"
+    interval = "2s"
+  }
+}
+	`, name, acctest.TestImage, port, timeout)
+}
+
+func testAccInstance_desiredState(name string, desiredState string) string {
+	return fmt.Sprintf(`
+resource "incus_instance" "instance1" {
+  name          = "%s"
+  image         = "%s"
+  type          = "container"
+  desired_state = "%s"
+}
+	`, name, acctest.TestImage, desiredState)
+}
+
+func testAccInstance_desiredStateWithRunning(name string) string {
+	return fmt.Sprintf(`
+resource "incus_instance" "instance1" {
+  name          = "%s"
+  image         = "%s"
+  running       = true
+  desired_state = "running"
+}
+	`, name, acctest.TestImage)
+}
+
+func testAccInstance_statefulStop(name string, desiredState string) string {
+	return fmt.Sprintf(`
+resource "incus_instance" "instance1" {
+  name          = "%s"
+  image         = "%s"
+  type          = "virtual-machine"
+  desired_state = "%s"
+  stateful_stop = true
+
+  config = {
+    "security.secureboot" = false
+    "migration.stateful"  = true
+  }
+
+  wait_for {
+    type = "agent"
+  }
+}
+	`, name, acctest.TestImage, desiredState)
+}
+
+func testAccInstance_restoreSnapshot(instanceName string, snapshotName string, restoreSnapshot string, restoreTrigger string) string {
+	var restore string
+	if restoreSnapshot != "" {
+		restore = fmt.Sprintf("restore_snapshot = %q", restoreSnapshot)
+	}
+
+	if restoreTrigger != "" {
+		restore += fmt.Sprintf("\n  restore_trigger  = %q", restoreTrigger)
+	}
+
+	return fmt.Sprintf(`
+resource "incus_instance" "instance1" {
+  name  = "%s"
+  image = "%s"
+  %s
+}
+
+resource "incus_instance_snapshot" "snapshot1" {
+  name     = "%s"
+  instance = incus_instance.instance1.name
+}
+	`, instanceName, acctest.TestImage, restore, snapshotName)
+}
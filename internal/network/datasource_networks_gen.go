@@ -0,0 +1,186 @@
+// Code generated by generate-datasources; DO NOT EDIT.
+
+package network
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/lxc/incus/v7/shared/api"
+
+	"github.com/lxc/terraform-provider-incus/internal/errors"
+	provider_config "github.com/lxc/terraform-provider-incus/internal/provider-config"
+)
+
+type NetworksDataSourceModel struct {
+	Project types.String `tfsdk:"project"`
+	Target  types.String `tfsdk:"target"`
+	Remote  types.String `tfsdk:"remote"`
+	Filters types.List   `tfsdk:"filters"`
+
+	Networks types.List `tfsdk:"networks"`
+}
+
+type NetworksDataSource struct {
+	provider *provider_config.IncusProviderConfig
+}
+
+func NewNetworksDataSource() datasource.DataSource {
+	return &NetworksDataSource{}
+}
+
+func (d *NetworksDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_networks", req.ProviderTypeName)
+}
+
+func (d *NetworksDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"project": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+
+			"remote": schema.StringAttribute{
+				Optional: true,
+			},
+
+			"target": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+
+			"filters": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+
+			"networks": schema.ListAttribute{
+				Computed:    true,
+				ElementType: getNetworksItemObjectType(),
+			},
+		},
+	}
+}
+
+func (d *NetworksDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	data := req.ProviderData
+	if data == nil {
+		return
+	}
+
+	provider, ok := data.(*provider_config.IncusProviderConfig)
+	if !ok {
+		resp.Diagnostics.Append(errors.NewProviderDataTypeError(req.ProviderData))
+		return
+	}
+
+	d.provider = provider
+}
+
+func (d *NetworksDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state NetworksDataSourceModel
+	var diags diag.Diagnostics
+
+	diags = req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	providerRemote := state.Remote.ValueString()
+	providerProjectName := state.Project.ValueString()
+	providerTarget := state.Target.ValueString()
+	server, err := d.provider.InstanceServer(providerRemote, providerProjectName, providerTarget)
+	if err != nil {
+		resp.Diagnostics.Append(errors.NewInstanceServerError(err))
+		return
+	}
+
+	filters := make([]string, 0, len(state.Filters.Elements()))
+	diags = state.Filters.ElementsAs(ctx, &filters, false)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	networks, err := server.GetNetworksWithFilter(filters)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to retrieve networks", err.Error())
+		return
+	}
+
+	networksList := make([]attr.Value, 0, len(networks))
+	for _, network := range networks {
+		networkValue, diags := toNetworksItemObjectValue(ctx, network)
+		resp.Diagnostics.Append(diags...)
+		if diags.HasError() {
+			return
+		}
+
+		networksList = append(networksList, networkValue)
+	}
+
+	state.Networks, diags = types.ListValue(getNetworksItemObjectType(), networksList)
+	resp.Diagnostics.Append(diags...)
+	if diags.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func getNetworksItemObjectType() types.ObjectType {
+	return types.ObjectType{
+		AttrTypes: map[string]attr.Type{
+			"name":        types.StringType,
+			"description": types.StringType,
+			"config": types.MapType{
+				ElemType: types.StringType,
+			},
+			"status": types.StringType,
+			"locations": types.ListType{
+				ElemType: types.StringType,
+			},
+			"type":    types.StringType,
+			"managed": types.BoolType,
+		},
+	}
+}
+
+func toNetworksItemObjectValue(ctx context.Context, network api.Network) (obj basetypes.ObjectValue, diags diag.Diagnostics) {
+	networksItemObjectType := getNetworksItemObjectType()
+	nilObject := types.ObjectNull(networksItemObjectType.AttrTypes)
+
+	res := map[string]attr.Value{}
+	res["name"] = types.StringValue(network.Name)
+	res["description"] = types.StringValue(network.Description)
+	res["status"] = types.StringValue(network.Status)
+	res["type"] = types.StringValue(network.Type)
+	res["managed"] = types.BoolValue(network.Managed)
+
+	res["config"], diags = types.MapValueFrom(ctx, types.StringType, network.Config)
+	if diags.HasError() {
+		return nilObject, diags
+	}
+
+	res["locations"], diags = types.ListValueFrom(ctx, types.StringType, network.Locations)
+	if diags.HasError() {
+		return nilObject, diags
+	}
+
+	return types.ObjectValue(networksItemObjectType.AttrTypes, res)
+}
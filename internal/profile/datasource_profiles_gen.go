@@ -0,0 +1,179 @@
+// Code generated by generate-datasources; DO NOT EDIT.
+
+package profile
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/lxc/incus/v7/shared/api"
+
+	"github.com/lxc/terraform-provider-incus/internal/common"
+	"github.com/lxc/terraform-provider-incus/internal/errors"
+	provider_config "github.com/lxc/terraform-provider-incus/internal/provider-config"
+)
+
+type ProfilesDataSourceModel struct {
+	Project types.String `tfsdk:"project"`
+	Remote  types.String `tfsdk:"remote"`
+	Filters types.List   `tfsdk:"filters"`
+
+	Profiles types.List `tfsdk:"profiles"`
+}
+
+type ProfilesDataSource struct {
+	provider *provider_config.IncusProviderConfig
+}
+
+func NewProfilesDataSource() datasource.DataSource {
+	return &ProfilesDataSource{}
+}
+
+func (d *ProfilesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_profiles", req.ProviderTypeName)
+}
+
+func (d *ProfilesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"project": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+
+			"remote": schema.StringAttribute{
+				Optional: true,
+			},
+
+			"filters": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+
+			"profiles": schema.ListAttribute{
+				Computed:    true,
+				ElementType: getProfilesItemObjectType(),
+			},
+		},
+	}
+}
+
+func (d *ProfilesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	data := req.ProviderData
+	if data == nil {
+		return
+	}
+
+	provider, ok := data.(*provider_config.IncusProviderConfig)
+	if !ok {
+		resp.Diagnostics.Append(errors.NewProviderDataTypeError(req.ProviderData))
+		return
+	}
+
+	d.provider = provider
+}
+
+func (d *ProfilesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state ProfilesDataSourceModel
+	var diags diag.Diagnostics
+
+	diags = req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	providerRemote := state.Remote.ValueString()
+	providerProjectName := state.Project.ValueString()
+	providerTarget := ""
+	server, err := d.provider.InstanceServer(providerRemote, providerProjectName, providerTarget)
+	if err != nil {
+		resp.Diagnostics.Append(errors.NewInstanceServerError(err))
+		return
+	}
+
+	filters := make([]string, 0, len(state.Filters.Elements()))
+	diags = state.Filters.ElementsAs(ctx, &filters, false)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	profiles, err := server.GetProfilesWithFilter(filters)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to retrieve profiles", err.Error())
+		return
+	}
+
+	profilesList := make([]attr.Value, 0, len(profiles))
+	for _, profile := range profiles {
+		profileValue, diags := toProfilesItemObjectValue(ctx, profile)
+		resp.Diagnostics.Append(diags...)
+		if diags.HasError() {
+			return
+		}
+
+		profilesList = append(profilesList, profileValue)
+	}
+
+	state.Profiles, diags = types.ListValue(getProfilesItemObjectType(), profilesList)
+	resp.Diagnostics.Append(diags...)
+	if diags.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func getProfilesItemObjectType() types.ObjectType {
+	return types.ObjectType{
+		AttrTypes: map[string]attr.Type{
+			"name":        types.StringType,
+			"description": types.StringType,
+			"config": types.MapType{
+				ElemType: types.StringType,
+			},
+			"device": types.SetType{
+				ElemType: types.ObjectType{
+					AttrTypes: map[string]attr.Type{
+						"name":       types.StringType,
+						"type":       types.StringType,
+						"properties": types.MapType{ElemType: types.StringType},
+					},
+				},
+			},
+		},
+	}
+}
+
+func toProfilesItemObjectValue(ctx context.Context, profile api.Profile) (obj basetypes.ObjectValue, diags diag.Diagnostics) {
+	profilesItemObjectType := getProfilesItemObjectType()
+	nilObject := types.ObjectNull(profilesItemObjectType.AttrTypes)
+
+	res := map[string]attr.Value{}
+	res["name"] = types.StringValue(profile.Name)
+	res["description"] = types.StringValue(profile.Description)
+
+	res["config"], diags = types.MapValueFrom(ctx, types.StringType, profile.Config)
+	if diags.HasError() {
+		return nilObject, diags
+	}
+
+	res["device"], diags = common.ToDeviceSetType(ctx, profile.Devices)
+	if diags.HasError() {
+		return nilObject, diags
+	}
+
+	return types.ObjectValue(profilesItemObjectType.AttrTypes, res)
+}
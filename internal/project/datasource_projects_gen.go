@@ -0,0 +1,154 @@
+// Code generated by generate-datasources; DO NOT EDIT.
+
+package project
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/lxc/incus/v7/shared/api"
+
+	"github.com/lxc/terraform-provider-incus/internal/errors"
+	provider_config "github.com/lxc/terraform-provider-incus/internal/provider-config"
+)
+
+type ProjectsDataSourceModel struct {
+	Remote  types.String `tfsdk:"remote"`
+	Filters types.List   `tfsdk:"filters"`
+
+	Projects types.List `tfsdk:"projects"`
+}
+
+type ProjectsDataSource struct {
+	provider *provider_config.IncusProviderConfig
+}
+
+func NewProjectsDataSource() datasource.DataSource {
+	return &ProjectsDataSource{}
+}
+
+func (d *ProjectsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_projects", req.ProviderTypeName)
+}
+
+func (d *ProjectsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"remote": schema.StringAttribute{
+				Optional: true,
+			},
+
+			"filters": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+
+			"projects": schema.ListAttribute{
+				Computed:    true,
+				ElementType: getProjectsItemObjectType(),
+			},
+		},
+	}
+}
+
+func (d *ProjectsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	data := req.ProviderData
+	if data == nil {
+		return
+	}
+
+	provider, ok := data.(*provider_config.IncusProviderConfig)
+	if !ok {
+		resp.Diagnostics.Append(errors.NewProviderDataTypeError(req.ProviderData))
+		return
+	}
+
+	d.provider = provider
+}
+
+func (d *ProjectsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state ProjectsDataSourceModel
+	var diags diag.Diagnostics
+
+	diags = req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	providerRemote := state.Remote.ValueString()
+	providerProjectName := ""
+	providerTarget := ""
+	server, err := d.provider.InstanceServer(providerRemote, providerProjectName, providerTarget)
+	if err != nil {
+		resp.Diagnostics.Append(errors.NewInstanceServerError(err))
+		return
+	}
+
+	filters := make([]string, 0, len(state.Filters.Elements()))
+	diags = state.Filters.ElementsAs(ctx, &filters, false)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projects, err := server.GetProjectsWithFilter(filters)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to retrieve projects", err.Error())
+		return
+	}
+
+	projectsList := make([]attr.Value, 0, len(projects))
+	for _, project := range projects {
+		projectValue, diags := toProjectsItemObjectValue(ctx, project)
+		resp.Diagnostics.Append(diags...)
+		if diags.HasError() {
+			return
+		}
+
+		projectsList = append(projectsList, projectValue)
+	}
+
+	state.Projects, diags = types.ListValue(getProjectsItemObjectType(), projectsList)
+	resp.Diagnostics.Append(diags...)
+	if diags.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func getProjectsItemObjectType() types.ObjectType {
+	return types.ObjectType{
+		AttrTypes: map[string]attr.Type{
+			"name":        types.StringType,
+			"description": types.StringType,
+			"config": types.MapType{
+				ElemType: types.StringType,
+			},
+		},
+	}
+}
+
+func toProjectsItemObjectValue(ctx context.Context, project api.Project) (obj basetypes.ObjectValue, diags diag.Diagnostics) {
+	projectsItemObjectType := getProjectsItemObjectType()
+	nilObject := types.ObjectNull(projectsItemObjectType.AttrTypes)
+
+	res := map[string]attr.Value{}
+	res["name"] = types.StringValue(project.Name)
+	res["description"] = types.StringValue(project.Description)
+
+	res["config"], diags = types.MapValueFrom(ctx, types.StringType, project.Config)
+	if diags.HasError() {
+		return nilObject, diags
+	}
+
+	return types.ObjectValue(projectsItemObjectType.AttrTypes, res)
+}
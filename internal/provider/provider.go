@@ -8,6 +8,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
@@ -17,8 +18,10 @@ import (
 	incus_config "github.com/lxc/incus/v7/shared/cliconfig"
 	incus_shared "github.com/lxc/incus/v7/shared/util"
 
+	"github.com/lxc/terraform-provider-incus/internal/auth"
 	"github.com/lxc/terraform-provider-incus/internal/certificate"
 	"github.com/lxc/terraform-provider-incus/internal/cluster"
+	"github.com/lxc/terraform-provider-incus/internal/functions"
 	"github.com/lxc/terraform-provider-incus/internal/image"
 	"github.com/lxc/terraform-provider-incus/internal/instance"
 	"github.com/lxc/terraform-provider-incus/internal/network"
@@ -287,11 +290,21 @@ func (p *IncusProvider) Configure(ctx context.Context, req provider.ConfigureReq
 
 func (p *IncusProvider) Resources(_ context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
+		auth.NewAuthGroupResource,
+		auth.NewIdentityResource,
+		auth.NewIdentityProviderGroupResource,
 		certificate.NewCertificateResource,
+		certificate.NewCertificateTokenResource,
 		cluster.NewClusterGroupResource,
+		cluster.NewClusterJoinTokenResource,
+		cluster.NewClusterMemberResource,
 		image.NewImageResource,
 		instance.NewInstanceResource,
+		instance.NewInstanceExecResource,
+		instance.NewInstanceFileResource,
 		instance.NewInstanceSnapshotResource,
+		instance.NewInstanceSnapshotPolicyResource,
+		instance.NewInstanceBackupResource,
 		network.NewNetworkACLResource,
 		network.NewNetworkForwardResource,
 		network.NewNetworkAddressSet,
@@ -307,13 +320,24 @@ func (p *IncusProvider) Resources(_ context.Context) []func() resource.Resource
 		storage.NewStorageBucketKeyResource,
 		storage.NewStorageBucketResource,
 		storage.NewStoragePoolResource,
+		storage.NewStorageVolumeBackupResource,
 		storage.NewStorageVolumeResource,
+		storage.NewStorageVolumeSnapshotResource,
 	}
 }
 
 func (p *IncusProvider) DataSources(_ context.Context) []func() datasource.DataSource {
 	return append([]func() datasource.DataSource{
+		auth.NewIdentitiesDataSource,
 		cluster.NewClusterDataSource,
 		image.NewImageDataSource,
 	}, generatedDataSources()...)
 }
+
+func (p *IncusProvider) Functions(_ context.Context) []func() function.Function {
+	return []func() function.Function{
+		functions.NewFormatImportIDFunction,
+		functions.NewParseImageRefFunction,
+		functions.NewParseSizeFunction,
+	}
+}
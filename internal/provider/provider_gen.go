@@ -5,6 +5,7 @@ package provider
 import (
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/lxc/terraform-provider-incus/internal/certificate"
+	"github.com/lxc/terraform-provider-incus/internal/image"
 	"github.com/lxc/terraform-provider-incus/internal/instance"
 	"github.com/lxc/terraform-provider-incus/internal/network"
 	"github.com/lxc/terraform-provider-incus/internal/profile"
@@ -15,8 +16,11 @@ import (
 func generatedDataSources() []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		certificate.NewCertificateDataSource,
+		image.NewImagesDataSource,
 		instance.NewInstanceDataSource,
+		instance.NewInstancesDataSource,
 		network.NewNetworkDataSource,
+		network.NewNetworksDataSource,
 		network.NewNetworkACLDataSource,
 		network.NewNetworkAddressSetDataSource,
 		network.NewNetworkForwardDataSource,
@@ -25,9 +29,12 @@ func generatedDataSources() []func() datasource.DataSource {
 		network.NewNetworkPeerDataSource,
 		network.NewNetworkZoneDataSource,
 		profile.NewProfileDataSource,
+		profile.NewProfilesDataSource,
 		project.NewProjectDataSource,
+		project.NewProjectsDataSource,
 		storage.NewStorageBucketDataSource,
 		storage.NewStoragePoolDataSource,
 		storage.NewStorageVolumeDataSource,
+		storage.NewStorageVolumesDataSource,
 	}
 }
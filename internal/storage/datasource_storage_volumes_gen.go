@@ -0,0 +1,185 @@
+// Code generated by generate-datasources; DO NOT EDIT.
+
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/lxc/incus/v7/shared/api"
+
+	"github.com/lxc/terraform-provider-incus/internal/errors"
+	provider_config "github.com/lxc/terraform-provider-incus/internal/provider-config"
+)
+
+type StorageVolumesDataSourceModel struct {
+	StoragePool types.String `tfsdk:"storage_pool"`
+	Project     types.String `tfsdk:"project"`
+	Target      types.String `tfsdk:"target"`
+	Remote      types.String `tfsdk:"remote"`
+	Filters     types.List   `tfsdk:"filters"`
+
+	StorageVolumes types.List `tfsdk:"storage_volumes"`
+}
+
+type StorageVolumesDataSource struct {
+	provider *provider_config.IncusProviderConfig
+}
+
+func NewStorageVolumesDataSource() datasource.DataSource {
+	return &StorageVolumesDataSource{}
+}
+
+func (d *StorageVolumesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_storage_volumes", req.ProviderTypeName)
+}
+
+func (d *StorageVolumesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"storage_pool": schema.StringAttribute{
+				Required: true,
+			},
+
+			"project": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+
+			"remote": schema.StringAttribute{
+				Optional: true,
+			},
+
+			"target": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+
+			"filters": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+
+			"storage_volumes": schema.ListAttribute{
+				Computed:    true,
+				ElementType: getStorageVolumesItemObjectType(),
+			},
+		},
+	}
+}
+
+func (d *StorageVolumesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	data := req.ProviderData
+	if data == nil {
+		return
+	}
+
+	provider, ok := data.(*provider_config.IncusProviderConfig)
+	if !ok {
+		resp.Diagnostics.Append(errors.NewProviderDataTypeError(req.ProviderData))
+		return
+	}
+
+	d.provider = provider
+}
+
+func (d *StorageVolumesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state StorageVolumesDataSourceModel
+	var diags diag.Diagnostics
+
+	diags = req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	providerRemote := state.Remote.ValueString()
+	providerProjectName := state.Project.ValueString()
+	providerTarget := state.Target.ValueString()
+	server, err := d.provider.InstanceServer(providerRemote, providerProjectName, providerTarget)
+	if err != nil {
+		resp.Diagnostics.Append(errors.NewInstanceServerError(err))
+		return
+	}
+
+	filters := make([]string, 0, len(state.Filters.Elements()))
+	diags = state.Filters.ElementsAs(ctx, &filters, false)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	storagePoolName := state.StoragePool.ValueString()
+
+	storageVolumes, err := server.GetStoragePoolVolumesWithFilter(storagePoolName, filters)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to retrieve storage volumes", err.Error())
+		return
+	}
+
+	storageVolumesList := make([]attr.Value, 0, len(storageVolumes))
+	for _, storageVolume := range storageVolumes {
+		storageVolumeValue, diags := toStorageVolumesItemObjectValue(ctx, storageVolume)
+		resp.Diagnostics.Append(diags...)
+		if diags.HasError() {
+			return
+		}
+
+		storageVolumesList = append(storageVolumesList, storageVolumeValue)
+	}
+
+	state.StorageVolumes, diags = types.ListValue(getStorageVolumesItemObjectType(), storageVolumesList)
+	resp.Diagnostics.Append(diags...)
+	if diags.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func getStorageVolumesItemObjectType() types.ObjectType {
+	return types.ObjectType{
+		AttrTypes: map[string]attr.Type{
+			"name":        types.StringType,
+			"type":        types.StringType,
+			"description": types.StringType,
+			"config": types.MapType{
+				ElemType: types.StringType,
+			},
+			"location":     types.StringType,
+			"content_type": types.StringType,
+		},
+	}
+}
+
+func toStorageVolumesItemObjectValue(ctx context.Context, storageVolume api.StorageVolume) (obj basetypes.ObjectValue, diags diag.Diagnostics) {
+	storageVolumesItemObjectType := getStorageVolumesItemObjectType()
+	nilObject := types.ObjectNull(storageVolumesItemObjectType.AttrTypes)
+
+	res := map[string]attr.Value{}
+	res["name"] = types.StringValue(storageVolume.Name)
+	res["type"] = types.StringValue(storageVolume.Type)
+	res["description"] = types.StringValue(storageVolume.Description)
+	res["location"] = types.StringValue(storageVolume.Location)
+	res["content_type"] = types.StringValue(storageVolume.ContentType)
+
+	res["config"], diags = types.MapValueFrom(ctx, types.StringType, storageVolume.Config)
+	if diags.HasError() {
+		return nilObject, diags
+	}
+
+	return types.ObjectValue(storageVolumesItemObjectType.AttrTypes, res)
+}
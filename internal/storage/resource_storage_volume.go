@@ -6,6 +6,7 @@ import (
 	"os"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
@@ -29,21 +30,23 @@ import (
 	"github.com/lxc/terraform-provider-incus/internal/common"
 	"github.com/lxc/terraform-provider-incus/internal/errors"
 	provider_config "github.com/lxc/terraform-provider-incus/internal/provider-config"
+	"github.com/lxc/terraform-provider-incus/internal/utils"
 )
 
 type StorageVolumeModel struct {
-	Name         types.String `tfsdk:"name"`
-	Description  types.String `tfsdk:"description"`
-	Pool         types.String `tfsdk:"pool"`
-	Type         types.String `tfsdk:"type"`
-	ContentType  types.String `tfsdk:"content_type"`
-	Project      types.String `tfsdk:"project"`
-	Target       types.String `tfsdk:"target"`
-	Remote       types.String `tfsdk:"remote"`
-	Config       types.Map    `tfsdk:"config"`
-	SourceVolume types.Object `tfsdk:"source_volume"`
-	SourceFile   types.String `tfsdk:"source_file"`
-	Files        types.Set    `tfsdk:"file"`
+	Name         types.String   `tfsdk:"name"`
+	Description  types.String   `tfsdk:"description"`
+	Pool         types.String   `tfsdk:"pool"`
+	Type         types.String   `tfsdk:"type"`
+	ContentType  types.String   `tfsdk:"content_type"`
+	Project      types.String   `tfsdk:"project"`
+	Target       types.String   `tfsdk:"target"`
+	Remote       types.String   `tfsdk:"remote"`
+	Config       types.Map      `tfsdk:"config"`
+	SourceVolume types.Object   `tfsdk:"source_volume"`
+	SourceFile   types.String   `tfsdk:"source_file"`
+	Files        types.Set      `tfsdk:"file"`
+	Timeouts     timeouts.Value `tfsdk:"timeouts"`
 
 	// Computed.
 	Location types.String `tfsdk:"location"`
@@ -55,9 +58,10 @@ type StorageVolumeResource struct {
 }
 
 type SourceVolumeModel struct {
-	Pool   types.String `tfsdk:"pool"`
-	Name   types.String `tfsdk:"name"`
-	Remote types.String `tfsdk:"remote"`
+	Pool     types.String `tfsdk:"pool"`
+	Name     types.String `tfsdk:"name"`
+	Remote   types.String `tfsdk:"remote"`
+	Snapshot types.String `tfsdk:"snapshot"`
 }
 
 // NewStorageVolumeResource returns a new storage volume resource.
@@ -69,7 +73,7 @@ func (r StorageVolumeResource) Metadata(_ context.Context, req resource.Metadata
 	resp.TypeName = fmt.Sprintf("%s_storage_volume", req.ProviderTypeName)
 }
 
-func (r StorageVolumeResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r StorageVolumeResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"name": schema.StringAttribute{
@@ -186,6 +190,12 @@ func (r StorageVolumeResource) Schema(_ context.Context, _ resource.SchemaReques
 					"remote": schema.StringAttribute{
 						Optional: true,
 					},
+					"snapshot": schema.StringAttribute{
+						Optional: true,
+						Validators: []validator.String{
+							stringvalidator.LengthAtLeast(1),
+						},
+					},
 				},
 				PlanModifiers: []planmodifier.Object{
 					objectplanmodifier.RequiresReplace(),
@@ -254,6 +264,18 @@ func (r StorageVolumeResource) Schema(_ context.Context, _ resource.SchemaReques
 							Optional: true,
 						},
 
+						"source_dir": schema.StringAttribute{
+							Optional: true,
+						},
+
+						"delete_removed": schema.BoolAttribute{
+							Optional: true,
+						},
+
+						"source_dir_hash": schema.StringAttribute{
+							Computed: true,
+						},
+
 						// Append is here just to satisfy the IncusFile model.
 						"append": schema.BoolAttribute{
 							Computed: true,
@@ -262,6 +284,12 @@ func (r StorageVolumeResource) Schema(_ context.Context, _ resource.SchemaReques
 					},
 				},
 			},
+
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Delete: true,
+			}),
 		},
 	}
 }
@@ -281,6 +309,25 @@ func (r *StorageVolumeResource) Configure(_ context.Context, req resource.Config
 	r.provider = provider
 }
 
+func (r StorageVolumeResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// If resource is being destroyed req.Config will be null.
+	// In such case there is no need for plan modification.
+	if req.Config.Raw.IsNull() {
+		return
+	}
+
+	// Detect changes to local directory trees pushed using source_dir.
+	var files types.Set
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("file"), &files)...)
+	files, diags := common.PlanSourceDirHashes(ctx, files)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("file"), files)...)
+}
+
 func (r StorageVolumeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var plan StorageVolumeModel
 
@@ -290,6 +337,15 @@ func (r StorageVolumeResource) Create(ctx context.Context, req resource.CreateRe
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, 0)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := utils.ContextWithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	if !plan.SourceVolume.IsNull() {
 		r.copyStoragePoolVolume(ctx, resp, &plan)
 		return
@@ -374,6 +430,11 @@ func (r StorageVolumeResource) copyStoragePoolVolume(ctx context.Context, resp *
 	srcName := sourceVolumeModel.Name.ValueString()
 	srcPool := sourceVolumeModel.Pool.ValueString()
 
+	// Copying from a snapshot restores its content into the new volume.
+	if sourceVolumeModel.Snapshot.ValueString() != "" {
+		srcName = fmt.Sprintf("%s/%s", srcName, sourceVolumeModel.Snapshot.ValueString())
+	}
+
 	dstVolID := fmt.Sprintf("%s/%s", dstPool, dstName)
 	srcVolID := fmt.Sprintf("%s/%s", srcPool, srcName)
 
@@ -393,7 +454,7 @@ func (r StorageVolumeResource) copyStoragePoolVolume(ctx context.Context, resp *
 		return
 	}
 
-	err = opCopy.Wait()
+	err = opCopy.WaitContext(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError(fmt.Sprintf("Failed to copy storage volume %q -> %q", srcVolID, dstVolID), err.Error())
 		return
@@ -426,8 +487,11 @@ func (r StorageVolumeResource) uploadFilesOnStoragePoolVolume(ctx context.Contex
 		volumeType := plan.Type.ValueString()
 		volumeName := plan.Name.ValueString()
 
+		fileTarget := common.NewVolumeFileTarget(server, poolName, volumeType, volumeName)
+		defer fileTarget.Close()
+
 		for _, f := range files {
-			err := common.VolumeFileUpload(server, poolName, volumeType, volumeName, f)
+			err := fileTarget.Upload(f)
 			if err != nil {
 				resp.Diagnostics.AddError(fmt.Sprintf("Failed to upload file to volume %q in pool %q", volumeName, poolName), err.Error())
 				return
@@ -475,7 +539,7 @@ func (r StorageVolumeResource) importStoragePoolVolume(ctx context.Context, resp
 		return
 	}
 
-	err = opImport.Wait()
+	err = opImport.WaitContext(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError(fmt.Sprintf("Failed to create storage volume from file %q", volName), err.Error())
 		return
@@ -523,6 +587,15 @@ func (r StorageVolumeResource) Update(ctx context.Context, req resource.UpdateRe
 		return
 	}
 
+	updateTimeout, diags := plan.Timeouts.Update(ctx, 0)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := utils.ContextWithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	remote := plan.Remote.ValueString()
 	project := plan.Project.ValueString()
 	target := plan.Target.ValueString()
@@ -574,15 +647,19 @@ func (r StorageVolumeResource) Update(ctx context.Context, req resource.UpdateRe
 		return
 	}
 
-	// Remove files that are no longer present in newFiles.
+	fileTarget := common.NewVolumeFileTarget(server, poolName, volType, volName)
+	defer fileTarget.Close()
+
+	// Remove files that are no longer present in newFiles. Directory trees
+	// are kept, as the target directory may contain other files.
 	for k, f := range oldFiles {
 		_, ok := newFiles[k]
-		if ok {
+		if ok || f.IsDirectory() {
 			continue
 		}
 
 		targetPath := f.TargetPath.ValueString()
-		err := common.VolumeFileDelete(server, poolName, volType, volName, targetPath)
+		err := fileTarget.Delete(targetPath)
 		if err != nil {
 			resp.Diagnostics.AddError(fmt.Sprintf("Failed to delete file from volume %q", targetResource), err.Error())
 			return
@@ -594,7 +671,7 @@ func (r StorageVolumeResource) Update(ctx context.Context, req resource.UpdateRe
 		oldFile, exists := oldFiles[k]
 
 		if !exists {
-			err := common.VolumeFileUpload(server, poolName, volType, volName, newFile)
+			err := fileTarget.Upload(newFile)
 			if err != nil {
 				resp.Diagnostics.AddError(fmt.Sprintf("Failed to upload file to volume %q", targetResource), err.Error())
 				return
@@ -605,17 +682,24 @@ func (r StorageVolumeResource) Update(ctx context.Context, req resource.UpdateRe
 		contentChanged := common.HasFileContentChanged(newFile, oldFile)
 		permissionsChanged := common.HasFilePermissionChanged(newFile, oldFile)
 
-		if contentChanged || permissionsChanged {
+		if (contentChanged || permissionsChanged) && newFile.IsDirectory() {
+			// Directory trees are synced in place.
+			err := fileTarget.Upload(newFile)
+			if err != nil {
+				resp.Diagnostics.AddError(fmt.Sprintf("Failed to upload updated file to volume %q", targetResource), err.Error())
+				return
+			}
+		} else if contentChanged || permissionsChanged {
 			// Delete the old file first otherwise mode and ownership changes
 			// will not be applied.
 			targetPath := newFile.TargetPath.ValueString()
-			err := common.VolumeFileDelete(server, poolName, volType, volName, targetPath)
+			err := fileTarget.Delete(targetPath)
 			if err != nil {
 				resp.Diagnostics.AddError(fmt.Sprintf("Failed to delete file from volume %q", targetResource), err.Error())
 				return
 			}
 
-			err = common.VolumeFileUpload(server, poolName, volType, volName, newFile)
+			err = fileTarget.Upload(newFile)
 			if err != nil {
 				resp.Diagnostics.AddError(fmt.Sprintf("Failed to upload updated file to volume %q", targetResource), err.Error())
 				return
@@ -637,6 +721,15 @@ func (r StorageVolumeResource) Delete(ctx context.Context, req resource.DeleteRe
 		return
 	}
 
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, 0)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := utils.ContextWithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	remote := state.Remote.ValueString()
 	project := state.Project.ValueString()
 	target := state.Target.ValueString()
@@ -0,0 +1,116 @@
+package storage_test
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	petname "github.com/dustinkirkland/golang-petname"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/lxc/terraform-provider-incus/internal/acctest"
+)
+
+func TestAccStorageVolumeBackup_basic(t *testing.T) {
+	poolName := petname.Generate(2, "-")
+	volumeName := petname.Generate(2, "-")
+	backupName := petname.Generate(2, "-")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStorageVolumeBackup_basic(poolName, volumeName, backupName, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_storage_volume_backup.backup1", "name", backupName),
+					resource.TestCheckResourceAttr("incus_storage_volume_backup.backup1", "pool", poolName),
+					resource.TestCheckResourceAttr("incus_storage_volume_backup.backup1", "volume", volumeName),
+					resource.TestCheckResourceAttr("incus_storage_volume_backup.backup1", "volume_only", "true"),
+					resource.TestCheckResourceAttr("incus_storage_volume_backup.backup1", "optimized_storage", "false"),
+					resource.TestCheckResourceAttr("incus_storage_volume_backup.backup1", "expires_at", "2030-01-01T00:00:00Z"),
+					resource.TestCheckResourceAttrSet("incus_storage_volume_backup.backup1", "created_at"),
+					resource.TestCheckNoResourceAttr("incus_storage_volume_backup.backup1", "sha256"),
+				),
+			},
+			{
+				ResourceName:                         "incus_storage_volume_backup.backup1",
+				ImportStateId:                        fmt.Sprintf("/%s/%s/%s", poolName, volumeName, backupName),
+				ImportStateVerifyIdentifierAttribute: "name",
+				ImportStateVerifyIgnore:              []string{"compression_algorithm"},
+				ImportStateVerify:                    true,
+				ImportState:                          true,
+			},
+		},
+	})
+}
+
+func TestAccStorageVolumeBackup_outputPath(t *testing.T) {
+	poolName := petname.Generate(2, "-")
+	volumeName := petname.Generate(2, "-")
+	backupName := petname.Generate(2, "-")
+	outputPath := filepath.Join(t.TempDir(), "backup.tar.gz")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStorageVolumeBackup_basic(poolName, volumeName, backupName, outputPath),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_storage_volume_backup.backup1", "name", backupName),
+					resource.TestCheckResourceAttr("incus_storage_volume_backup.backup1", "output_path", outputPath),
+					resource.TestMatchResourceAttr("incus_storage_volume_backup.backup1", "sha256", regexp.MustCompile(`^[0-9a-f]{64}$`)),
+				),
+			},
+			{
+				// Restore the downloaded backup into a new volume.
+				Config: testAccStorageVolumeBackup_basic(poolName, volumeName, backupName, outputPath) + testAccStorageVolumeBackup_restore(volumeName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_storage_volume.restored", "name", fmt.Sprintf("%s-restored", volumeName)),
+					resource.TestCheckResourceAttr("incus_storage_volume.restored", "source_file", outputPath),
+				),
+			},
+		},
+	})
+}
+
+func testAccStorageVolumeBackup_basic(poolName, volumeName, backupName, outputPath string) string {
+	output := ""
+	if outputPath != "" {
+		output = fmt.Sprintf("output_path = %q", outputPath)
+	}
+
+	return fmt.Sprintf(`
+resource "incus_storage_pool" "pool1" {
+  name   = "%s"
+  driver = "dir"
+}
+
+resource "incus_storage_volume" "volume1" {
+  name = "%s"
+  pool = incus_storage_pool.pool1.name
+}
+
+resource "incus_storage_volume_backup" "backup1" {
+  name                  = "%s"
+  pool                  = incus_storage_volume.volume1.pool
+  volume                = incus_storage_volume.volume1.name
+  volume_only           = true
+  compression_algorithm = "gzip"
+  expires_at            = "2030-01-01T00:00:00Z"
+  %s
+}
+	`, poolName, volumeName, backupName, output)
+}
+
+func testAccStorageVolumeBackup_restore(volumeName string) string {
+	return fmt.Sprintf(`
+resource "incus_storage_volume" "restored" {
+  name        = "%s-restored"
+  pool        = incus_storage_pool.pool1.name
+  source_file = incus_storage_volume_backup.backup1.output_path
+}
+	`, volumeName)
+}
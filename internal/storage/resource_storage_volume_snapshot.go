@@ -0,0 +1,370 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	incus "github.com/lxc/incus/v7/client"
+	"github.com/lxc/incus/v7/shared/api"
+
+	"github.com/lxc/terraform-provider-incus/internal/common"
+	"github.com/lxc/terraform-provider-incus/internal/errors"
+	provider_config "github.com/lxc/terraform-provider-incus/internal/provider-config"
+)
+
+// storageVolumeSnapshotVolumeType is the only volume type that supports
+// user managed snapshots.
+const storageVolumeSnapshotVolumeType = "custom"
+
+type StorageVolumeSnapshotModel struct {
+	Name        types.String `tfsdk:"name"`
+	Pool        types.String `tfsdk:"pool"`
+	Volume      types.String `tfsdk:"volume"`
+	Description types.String `tfsdk:"description"`
+	ExpiresAt   types.String `tfsdk:"expires_at"`
+	Project     types.String `tfsdk:"project"`
+	Remote      types.String `tfsdk:"remote"`
+
+	// Computed.
+	CreatedAt types.Int64 `tfsdk:"created_at"`
+}
+
+// StorageVolumeSnapshotResource represent Incus storage volume snapshot
+// resource.
+type StorageVolumeSnapshotResource struct {
+	provider *provider_config.IncusProviderConfig
+}
+
+// NewStorageVolumeSnapshotResource returns a new storage volume snapshot
+// resource.
+func NewStorageVolumeSnapshotResource() resource.Resource {
+	return &StorageVolumeSnapshotResource{}
+}
+
+func (r StorageVolumeSnapshotResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_storage_volume_snapshot", req.ProviderTypeName)
+}
+
+func (r StorageVolumeSnapshotResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+
+			"pool": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+
+			"volume": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+
+			"description": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(""),
+			},
+
+			"expires_at": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					common.TimestampValidator{},
+				},
+			},
+
+			"project": schema.StringAttribute{
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+
+			"remote": schema.StringAttribute{
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+
+			// Computed.
+
+			"created_at": schema.Int64Attribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (r *StorageVolumeSnapshotResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	data := req.ProviderData
+	if data == nil {
+		return
+	}
+
+	provider, ok := data.(*provider_config.IncusProviderConfig)
+	if !ok {
+		resp.Diagnostics.Append(errors.NewProviderDataTypeError(req.ProviderData))
+		return
+	}
+
+	r.provider = provider
+}
+
+func (r StorageVolumeSnapshotResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan StorageVolumeSnapshotModel
+
+	// Fetch resource model from Terraform plan.
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	remote := plan.Remote.ValueString()
+	project := plan.Project.ValueString()
+	server, err := r.provider.InstanceServer(remote, project, "")
+	if err != nil {
+		resp.Diagnostics.Append(errors.NewInstanceServerError(err))
+		return
+	}
+
+	poolName := plan.Pool.ValueString()
+	volName := plan.Volume.ValueString()
+	snapshotName := plan.Name.ValueString()
+
+	expiresAt, err := common.ToTimestamp(plan.ExpiresAt)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("expires_at"), "Invalid timestamp", err.Error())
+		return
+	}
+
+	// Without an expiry, the zero time prevents the volume's default
+	// expiry from being applied.
+	if expiresAt == nil {
+		expiresAt = &time.Time{}
+	}
+
+	snapshotReq := api.StorageVolumeSnapshotsPost{
+		Name:      snapshotName,
+		ExpiresAt: expiresAt,
+	}
+
+	op, err := server.CreateStoragePoolVolumeSnapshot(poolName, storageVolumeSnapshotVolumeType, volName, snapshotReq)
+	if err == nil {
+		err = op.WaitContext(ctx)
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to create snapshot %q for storage volume %q", snapshotName, volName), err.Error())
+		return
+	}
+
+	// Snapshot description can only be set once the snapshot exists.
+	if plan.Description.ValueString() != "" {
+		diags = r.updateSnapshot(server, plan)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Update Terraform state.
+	diags = r.SyncState(ctx, &resp.State, server, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r StorageVolumeSnapshotResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state StorageVolumeSnapshotModel
+
+	// Fetch resource model from Terraform state.
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	remote := state.Remote.ValueString()
+	project := state.Project.ValueString()
+	server, err := r.provider.InstanceServer(remote, project, "")
+	if err != nil {
+		resp.Diagnostics.Append(errors.NewInstanceServerError(err))
+		return
+	}
+
+	// Update Terraform state.
+	diags = r.SyncState(ctx, &resp.State, server, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r StorageVolumeSnapshotResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan StorageVolumeSnapshotModel
+
+	// Fetch resource model from Terraform plan.
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	remote := plan.Remote.ValueString()
+	project := plan.Project.ValueString()
+	server, err := r.provider.InstanceServer(remote, project, "")
+	if err != nil {
+		resp.Diagnostics.Append(errors.NewInstanceServerError(err))
+		return
+	}
+
+	diags = r.updateSnapshot(server, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Update Terraform state.
+	diags = r.SyncState(ctx, &resp.State, server, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r StorageVolumeSnapshotResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state StorageVolumeSnapshotModel
+
+	// Fetch resource model from Terraform state.
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	remote := state.Remote.ValueString()
+	project := state.Project.ValueString()
+	server, err := r.provider.InstanceServer(remote, project, "")
+	if err != nil {
+		resp.Diagnostics.Append(errors.NewInstanceServerError(err))
+		return
+	}
+
+	poolName := state.Pool.ValueString()
+	volName := state.Volume.ValueString()
+	snapshotName := state.Name.ValueString()
+
+	op, err := server.DeleteStoragePoolVolumeSnapshot(poolName, storageVolumeSnapshotVolumeType, volName, snapshotName)
+	if err == nil {
+		err = op.WaitContext(ctx)
+	}
+
+	if err != nil && !errors.IsNotFoundError(err) {
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to remove snapshot %q for storage volume %q", snapshotName, volName), err.Error())
+	}
+}
+
+func (r StorageVolumeSnapshotResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	meta := common.ImportMetadata{
+		ResourceName:   "storage_volume_snapshot",
+		RequiredFields: []string{"pool", "volume", "name"},
+	}
+
+	fields, diag := meta.ParseImportID(req.ID)
+	if diag != nil {
+		resp.Diagnostics.Append(diag)
+		return
+	}
+
+	for k, v := range fields {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(k), v)...)
+	}
+}
+
+// updateSnapshot applies the description and expiry from the provided
+// model to an existing storage volume snapshot.
+func (r StorageVolumeSnapshotResource) updateSnapshot(server incus.InstanceServer, m StorageVolumeSnapshotModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	poolName := m.Pool.ValueString()
+	volName := m.Volume.ValueString()
+	snapshotName := m.Name.ValueString()
+
+	snapshot, etag, err := server.GetStoragePoolVolumeSnapshot(poolName, storageVolumeSnapshotVolumeType, volName, snapshotName)
+	if err != nil {
+		diags.AddError(fmt.Sprintf("Failed to retrieve snapshot %q for storage volume %q", snapshotName, volName), err.Error())
+		return diags
+	}
+
+	snapshotReq := snapshot.StorageVolumeSnapshotPut
+	snapshotReq.Description = m.Description.ValueString()
+
+	// The zero time removes the expiry.
+	expiresAt, err := common.ToTimestamp(m.ExpiresAt)
+	if err != nil {
+		diags.AddAttributeError(path.Root("expires_at"), "Invalid timestamp", err.Error())
+		return diags
+	}
+
+	if expiresAt == nil {
+		expiresAt = &time.Time{}
+	}
+
+	snapshotReq.ExpiresAt = expiresAt
+
+	err = server.UpdateStoragePoolVolumeSnapshot(poolName, storageVolumeSnapshotVolumeType, volName, snapshotName, snapshotReq, etag)
+	if err != nil {
+		diags.AddError(fmt.Sprintf("Failed to update snapshot %q for storage volume %q", snapshotName, volName), err.Error())
+	}
+
+	return diags
+}
+
+// SyncState fetches the server's current state for a storage volume snapshot
+// and updates the provided model. It then applies this updated model as the
+// new state in Terraform.
+func (r StorageVolumeSnapshotResource) SyncState(ctx context.Context, tfState *tfsdk.State, server incus.InstanceServer, m StorageVolumeSnapshotModel) diag.Diagnostics {
+	poolName := m.Pool.ValueString()
+	volName := m.Volume.ValueString()
+	snapshotName := m.Name.ValueString()
+
+	snapshot, _, err := server.GetStoragePoolVolumeSnapshot(poolName, storageVolumeSnapshotVolumeType, volName, snapshotName)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			tfState.RemoveResource(ctx)
+			return nil
+		}
+
+		return diag.Diagnostics{diag.NewErrorDiagnostic(
+			fmt.Sprintf("Failed to retrieve snapshot %q for storage volume %q", snapshotName, volName),
+			err.Error(),
+		)}
+	}
+
+	m.Description = types.StringValue(snapshot.Description)
+	m.ExpiresAt = common.ToTimestampType(snapshot.ExpiresAt, m.ExpiresAt)
+	m.CreatedAt = types.Int64Value(snapshot.CreatedAt.Unix())
+
+	return tfState.Set(ctx, &m)
+}
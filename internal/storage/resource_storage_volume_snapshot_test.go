@@ -0,0 +1,193 @@
+package storage_test
+
+import (
+	"fmt"
+	"testing"
+
+	petname "github.com/dustinkirkland/golang-petname"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/lxc/terraform-provider-incus/internal/acctest"
+)
+
+func TestAccStorageVolumeSnapshot_basic(t *testing.T) {
+	poolName := petname.Generate(2, "-")
+	volumeName := petname.Generate(2, "-")
+	snapshotName := petname.Generate(2, "-")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStorageVolumeSnapshot_basic(poolName, volumeName, snapshotName, "First snapshot"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_storage_volume_snapshot.snapshot1", "name", snapshotName),
+					resource.TestCheckResourceAttr("incus_storage_volume_snapshot.snapshot1", "pool", poolName),
+					resource.TestCheckResourceAttr("incus_storage_volume_snapshot.snapshot1", "volume", volumeName),
+					resource.TestCheckResourceAttr("incus_storage_volume_snapshot.snapshot1", "description", "First snapshot"),
+					resource.TestCheckResourceAttrSet("incus_storage_volume_snapshot.snapshot1", "created_at"),
+				),
+			},
+			{
+				Config: testAccStorageVolumeSnapshot_basic(poolName, volumeName, snapshotName, "Updated snapshot"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_storage_volume_snapshot.snapshot1", "name", snapshotName),
+					resource.TestCheckResourceAttr("incus_storage_volume_snapshot.snapshot1", "description", "Updated snapshot"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccStorageVolumeSnapshot_expiresAt(t *testing.T) {
+	poolName := petname.Generate(2, "-")
+	volumeName := petname.Generate(2, "-")
+	snapshotName := petname.Generate(2, "-")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStorageVolumeSnapshot_expiresAt(poolName, volumeName, snapshotName, "2099-01-01T00:00:00Z"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_storage_volume_snapshot.snapshot1", "name", snapshotName),
+					resource.TestCheckResourceAttr("incus_storage_volume_snapshot.snapshot1", "expires_at", "2099-01-01T00:00:00Z"),
+				),
+			},
+			{
+				Config: testAccStorageVolumeSnapshot_expiresAt(poolName, volumeName, snapshotName, "2099-06-01T12:00:00Z"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_storage_volume_snapshot.snapshot1", "expires_at", "2099-06-01T12:00:00Z"),
+				),
+			},
+			{
+				// Removing expires_at clears the expiry.
+				Config: testAccStorageVolumeSnapshot_basic(poolName, volumeName, snapshotName, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckNoResourceAttr("incus_storage_volume_snapshot.snapshot1", "expires_at"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccStorageVolumeSnapshot_restore(t *testing.T) {
+	poolName := petname.Generate(2, "-")
+	volumeName := petname.Generate(2, "-")
+	snapshotName := petname.Generate(2, "-")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStorageVolumeSnapshot_restore(poolName, volumeName, snapshotName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_storage_volume_snapshot.snapshot1", "name", snapshotName),
+					resource.TestCheckResourceAttr("incus_storage_volume.restored", "name", fmt.Sprintf("%s-restored", volumeName)),
+					resource.TestCheckResourceAttr("incus_storage_volume.restored", "source_volume.name", volumeName),
+					resource.TestCheckResourceAttr("incus_storage_volume.restored", "source_volume.snapshot", snapshotName),
+				),
+			},
+		},
+	})
+}
+
+func TestAccStorageVolumeSnapshot_importBasic(t *testing.T) {
+	poolName := petname.Generate(2, "-")
+	volumeName := petname.Generate(2, "-")
+	snapshotName := petname.Generate(2, "-")
+	resourceName := "incus_storage_volume_snapshot.snapshot1"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStorageVolumeSnapshot_basic(poolName, volumeName, snapshotName, ""),
+			},
+			{
+				ResourceName:                         resourceName,
+				ImportStateId:                        fmt.Sprintf("/%s/%s/%s", poolName, volumeName, snapshotName),
+				ImportStateVerifyIdentifierAttribute: "name",
+				ImportStateVerify:                    true,
+				ImportState:                          true,
+			},
+		},
+	})
+}
+
+func testAccStorageVolumeSnapshot_basic(poolName, volumeName, snapshotName, description string) string {
+	return fmt.Sprintf(`
+resource "incus_storage_pool" "pool1" {
+  name   = "%s"
+  driver = "dir"
+}
+
+resource "incus_storage_volume" "volume1" {
+  name = "%s"
+  pool = incus_storage_pool.pool1.name
+}
+
+resource "incus_storage_volume_snapshot" "snapshot1" {
+  name        = "%s"
+  pool        = incus_storage_volume.volume1.pool
+  volume      = incus_storage_volume.volume1.name
+  description = "%s"
+}
+	`, poolName, volumeName, snapshotName, description)
+}
+
+func testAccStorageVolumeSnapshot_expiresAt(poolName, volumeName, snapshotName, expiresAt string) string {
+	return fmt.Sprintf(`
+resource "incus_storage_pool" "pool1" {
+  name   = "%s"
+  driver = "dir"
+}
+
+resource "incus_storage_volume" "volume1" {
+  name = "%s"
+  pool = incus_storage_pool.pool1.name
+}
+
+resource "incus_storage_volume_snapshot" "snapshot1" {
+  name       = "%s"
+  pool       = incus_storage_volume.volume1.pool
+  volume     = incus_storage_volume.volume1.name
+  expires_at = "%s"
+}
+	`, poolName, volumeName, snapshotName, expiresAt)
+}
+
+func testAccStorageVolumeSnapshot_restore(poolName, volumeName, snapshotName string) string {
+	return fmt.Sprintf(`
+resource "incus_storage_pool" "pool1" {
+  name   = "%[1]s"
+  driver = "dir"
+}
+
+resource "incus_storage_volume" "volume1" {
+  name = "%[2]s"
+  pool = incus_storage_pool.pool1.name
+}
+
+resource "incus_storage_volume_snapshot" "snapshot1" {
+  name   = "%[3]s"
+  pool   = incus_storage_volume.volume1.pool
+  volume = incus_storage_volume.volume1.name
+}
+
+resource "incus_storage_volume" "restored" {
+  name = "%[2]s-restored"
+  pool = incus_storage_pool.pool1.name
+
+  source_volume = {
+    pool     = incus_storage_pool.pool1.name
+    name     = incus_storage_volume.volume1.name
+    snapshot = incus_storage_volume_snapshot.snapshot1.name
+  }
+}
+	`, poolName, volumeName, snapshotName)
+}
@@ -39,6 +39,17 @@ func ContextTimeout(ctx context.Context, def time.Duration) int {
 	return int(def.Seconds())
 }
 
+// ContextWithTimeout returns a copy of the parent context that is cancelled
+// once the given timeout elapses. If the timeout is not positive, the parent
+// deadline (if any) is kept, so callers fall back to their own defaults.
+func ContextWithTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+
+	return context.WithTimeout(ctx, timeout)
+}
+
 // HasAnyPrefix checks whether a value has any of the prefixes.
 func HasAnyPrefix(value string, prefixes []string) bool {
 	for _, p := range prefixes {